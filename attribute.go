@@ -5,6 +5,7 @@ package kmip
  * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
 
 import (
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -19,33 +20,79 @@ type Attribute struct {
 	Value interface{} `kmip:"ATTRIBUTE_VALUE"`
 }
 
+func init() {
+	RegisterAttribute(ATTRIBUTE_NAME_UNIQUE_IDENTIFIER, "")
+	RegisterAttribute(ATTRIBUTE_NAME_NAME, &Name{})
+	RegisterAttribute(ATTRIBUTE_NAME_OBJECT_TYPE, Enum(0))
+	RegisterAttribute(ATTRIBUTE_NAME_CRYPTOGRAPHIC_ALGORITHM, Enum(0))
+	RegisterAttribute(ATTRIBUTE_NAME_CRYPTOGRAPHIC_LENGTH, int32(0))
+	RegisterAttribute(ATTRIBUTE_NAME_CRYPTOGRAPHIC_PARAMETERS, &CryptoParams{})
+	RegisterAttribute(ATTRIBUTE_NAME_CRYPTOGRAPHIC_DOMAIN_PARAMETERS, &CryptographicDomainParameters{})
+	RegisterAttribute(ATTRIBUTE_NAME_CERTIFICATE_TYPE, Enum(0))
+	RegisterAttribute(ATTRIBUTE_NAME_CERTIFICATE_LENGTH, int32(0))
+	RegisterAttribute(ATTRIBUTE_NAME_X_509_CERTIFICATE_IDENTIFIER, &X509CertificateIdentifier{})
+	RegisterAttribute(ATTRIBUTE_NAME_X_509_CERTIFICATE_SUBJECT, &X509CertificateSubject{})
+	RegisterAttribute(ATTRIBUTE_NAME_X_509_CERTIFICATE_ISSUER, &X509CertificateIssuer{})
+	RegisterAttribute(ATTRIBUTE_NAME_CERTIFICATE_IDENTIFIER, &CertificateIdentifier{})
+	RegisterAttribute(ATTRIBUTE_NAME_CERTIFICATE_SUBJECT, &CertificateSubject{})
+	RegisterAttribute(ATTRIBUTE_NAME_CERTIFICATE_ISSUER, &CertificateIssuer{})
+	RegisterAttribute(ATTRIBUTE_NAME_DIGITAL_SIGNATURE_ALGORITHM, Enum(0))
+	RegisterAttribute(ATTRIBUTE_NAME_DIGEST, &Digest{})
+	RegisterAttribute(ATTRIBUTE_NAME_OPERATION_POLICY_NAME, "")
+	RegisterAttribute(ATTRIBUTE_NAME_CRYPTOGRAPHIC_USAGE_MASK, UsageMask(0))
+	RegisterAttribute(ATTRIBUTE_NAME_LEASE_TIME, time.Duration(0))
+	RegisterAttribute(ATTRIBUTE_NAME_USAGE_LIMITS, &UsageLimits{})
+	RegisterAttribute(ATTRIBUTE_NAME_STATE, Enum(0))
+	RegisterAttribute(ATTRIBUTE_NAME_INITIAL_DATE, time.Time{})
+	RegisterAttribute(ATTRIBUTE_NAME_ACTIVATION_DATE, time.Time{})
+	RegisterAttribute(ATTRIBUTE_NAME_PROCESS_START_DATE, time.Time{})
+	RegisterAttribute(ATTRIBUTE_NAME_PROTECT_STOP_DATE, time.Time{})
+	RegisterAttribute(ATTRIBUTE_NAME_DEACTIVATION_DATE, time.Time{})
+	RegisterAttribute(ATTRIBUTE_NAME_DESTROY_DATE, time.Time{})
+	RegisterAttribute(ATTRIBUTE_NAME_COMPROMISE_OCCURRENCE_DATE, time.Time{})
+	RegisterAttribute(ATTRIBUTE_NAME_COMPROMISE_DATE, time.Time{})
+	RegisterAttribute(ATTRIBUTE_NAME_REVOCATION_REASON, &RevocationReason{})
+	RegisterAttribute(ATTRIBUTE_NAME_ARCHIVE_DATE, time.Time{})
+	RegisterAttribute(ATTRIBUTE_NAME_OBJECT_GROUP, "")
+	RegisterAttribute(ATTRIBUTE_NAME_FRESH, false)
+	RegisterAttribute(ATTRIBUTE_NAME_LINK, &Link{})
+	RegisterAttribute(ATTRIBUTE_NAME_APPLICATION_SPECIFIC_INFORMATION, &ApplicationSpecificInformation{})
+	RegisterAttribute(ATTRIBUTE_NAME_CONTACT_INFORMATION, "")
+	RegisterAttribute(ATTRIBUTE_NAME_LAST_CHANGE_DATE, time.Time{})
+	RegisterAttribute(ATTRIBUTE_NAME_ALTERNATIVE_NAME, &AlternativeName{})
+	RegisterAttribute(ATTRIBUTE_NAME_KEY_VALUE_PRESENT, false)
+	RegisterAttribute(ATTRIBUTE_NAME_KEY_VALUE_LOCATION, &KeyValueLocation{})
+	RegisterAttribute(ATTRIBUTE_NAME_ORIGINAL_CREATION_DATE, time.Time{})
+}
+
 // BuildFieldValue builds dynamic Value field
+//
+// Value types are looked up in the registry, see RegisterAttribute. Values
+// of custom attributes (see IsCustomAttribute) which are not registered are
+// decoded according to the type on the wire (structures as TTLV). Values of other
+// unknown attributes are decoded as TTLV, so structure values of unknown attributes
+// are always TTLV (not *TTLV).
 func (a *Attribute) BuildFieldValue(name string) (v interface{}, err error) {
-	switch a.Name {
-	case ATTRIBUTE_NAME_CRYPTOGRAPHIC_ALGORITHM:
-		v = Enum(0)
-	case ATTRIBUTE_NAME_CRYPTOGRAPHIC_LENGTH, ATTRIBUTE_NAME_CRYPTOGRAPHIC_USAGE_MASK:
-		v = int32(0)
-	case ATTRIBUTE_NAME_UNIQUE_IDENTIFIER, ATTRIBUTE_NAME_OPERATION_POLICY_NAME:
-		v = ""
-	case ATTRIBUTE_NAME_OBJECT_TYPE, ATTRIBUTE_NAME_STATE:
-		v = Enum(0)
-	case ATTRIBUTE_NAME_INITIAL_DATE, ATTRIBUTE_NAME_LAST_CHANGE_DATE:
-		v = time.Time{}
-	case ATTRIBUTE_NAME_NAME:
-		v = &Name{}
-	case ATTRIBUTE_NAME_DIGEST:
-		v = &Digest{}
-	default:
-		err = errors.Errorf("unsupported attribute: %v", a.Name)
+	typ, ok := attributeRegistry[a.Name]
+	if !ok {
+		if !IsCustomAttribute(a.Name) {
+			v = TTLV{}
+		}
+
+		return
 	}
 
+	v = newValue(typ)
+
 	return
 }
 
 // Attributes is a sequence of Attribute objects which allows building and search
+//
+// Attributes with multiple instances (e.g. Link) are distinguished by Attribute.Index.
 type Attributes []Attribute
 
+// Get returns value of the first attribute instance by name (nil if not found)
 func (attrs Attributes) Get(name string) (val interface{}) {
 	for i := range attrs {
 		if attrs[i].Name == name {
@@ -57,6 +104,193 @@ func (attrs Attributes) Get(name string) (val interface{}) {
 	return
 }
 
+// GetIndexed returns value of the attribute instance with specified index
+func (attrs Attributes) GetIndexed(name string, idx int32) (val interface{}, ok bool) {
+	for i := range attrs {
+		if attrs[i].Name == name && attrs[i].Index == idx {
+			return attrs[i].Value, true
+		}
+	}
+
+	return
+}
+
+// GetAll returns values of all the instances of the attribute
+func (attrs Attributes) GetAll(name string) (vals []interface{}) {
+	for i := range attrs {
+		if attrs[i].Name == name {
+			vals = append(vals, attrs[i].Value)
+		}
+	}
+
+	return
+}
+
+// GetEnum returns value of the enumeration attribute (e.g. Cryptographic Algorithm)
+//
+// If the attribute is missing or has different type, ok is false.
+func (attrs Attributes) GetEnum(name string) (val Enum, ok bool) {
+	val, ok = attrs.Get(name).(Enum)
+
+	return
+}
+
+// GetInt32 returns value of the integer attribute (e.g. Cryptographic Length)
+//
+// If the attribute is missing or has different type, ok is false.
+func (attrs Attributes) GetInt32(name string) (val int32, ok bool) {
+	val, ok = attrs.Get(name).(int32)
+
+	return
+}
+
+// GetString returns value of the text string attribute (e.g. Object Group)
+//
+// If the attribute is missing or has different type, ok is false.
+func (attrs Attributes) GetString(name string) (val string, ok bool) {
+	val, ok = attrs.Get(name).(string)
+
+	return
+}
+
+// GetTime returns value of the date-time attribute (e.g. Activation Date)
+//
+// If the attribute is missing or has different type, ok is false.
+func (attrs Attributes) GetTime(name string) (val time.Time, ok bool) {
+	val, ok = attrs.Get(name).(time.Time)
+
+	return
+}
+
+// GetUsageMask returns value of the Cryptographic Usage Mask attribute
+//
+// Value might be set either as UsageMask or as int32. If the attribute is missing, ok is false.
+func (attrs Attributes) GetUsageMask() (val UsageMask, ok bool) {
+	switch v := attrs.Get(ATTRIBUTE_NAME_CRYPTOGRAPHIC_USAGE_MASK).(type) {
+	case UsageMask:
+		return v, true
+	case int32:
+		return UsageMask(v), true
+	}
+
+	return
+}
+
+// GetName returns value of the Name attribute
+//
+// If the attribute is missing, ok is false.
+func (attrs Attributes) GetName() (val Name, ok bool) {
+	switch v := attrs.Get(ATTRIBUTE_NAME_NAME).(type) {
+	case Name:
+		return v, true
+	case *Name:
+		if v != nil {
+			return *v, true
+		}
+	}
+
+	return
+}
+
+// Set sets value of the attribute instance with index 0, replacing existing value
+func (attrs *Attributes) Set(name string, val interface{}) {
+	for i := range *attrs {
+		if (*attrs)[i].Name == name && (*attrs)[i].Index == 0 {
+			(*attrs)[i].Value = val
+			return
+		}
+	}
+
+	*attrs = append(*attrs, Attribute{Name: name, Value: val})
+}
+
+// Add appends new instance of the attribute
+//
+// New instance gets index next to the highest existing index of the attribute
+// (or 0 for the first instance). Returned value is the index of the new instance.
+func (attrs *Attributes) Add(name string, val interface{}) (idx int32) {
+	found := false
+
+	for i := range *attrs {
+		if (*attrs)[i].Name == name && (!found || (*attrs)[i].Index >= idx) {
+			idx = (*attrs)[i].Index + 1
+			found = true
+		}
+	}
+
+	*attrs = append(*attrs, Attribute{Name: name, Index: idx, Value: val})
+
+	return
+}
+
+// Delete removes all the instances of the attribute
+func (attrs *Attributes) Delete(name string) {
+	res := (*attrs)[:0]
+
+	for i := range *attrs {
+		if (*attrs)[i].Name != name {
+			res = append(res, (*attrs)[i])
+		}
+	}
+
+	*attrs = res
+}
+
+// DeleteIndexed removes attribute instance with specified index
+//
+// Indexes of the remaining instances are not changed. Returns false if the
+// instance is not found.
+func (attrs *Attributes) DeleteIndexed(name string, idx int32) bool {
+	for i := range *attrs {
+		if (*attrs)[i].Name == name && (*attrs)[i].Index == idx {
+			*attrs = append((*attrs)[:i], (*attrs)[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetCustom returns value of the custom attribute by name
+//
+// Value is decoded according to the type on the wire: e.g. string for
+// Text String, int32 for Integer and TTLV for structures.
+func (attrs Attributes) GetCustom(name string) (val interface{}, ok bool) {
+	if !IsCustomAttribute(name) {
+		return
+	}
+
+	for i := range attrs {
+		if attrs[i].Name == name {
+			return attrs[i].Value, true
+		}
+	}
+
+	return
+}
+
+// SetCustom sets value of the custom attribute (index 0), replacing existing one
+//
+// Value might be any value supported by the Encoder (e.g. string, int32,
+// time.Time, TTLV or struct with `kmip` tags).
+func (attrs *Attributes) SetCustom(name string, val interface{}) error {
+	if !IsCustomAttribute(name) {
+		return errors.Errorf("attribute name %q is not a custom attribute name", name)
+	}
+
+	attrs.Set(name, val)
+
+	return nil
+}
+
+// IsCustomAttribute returns true if the name is a custom attribute name
+//
+// Client custom attributes are prefixed with "x-", server custom attributes
+// are prefixed with "y-".
+func IsCustomAttribute(name string) bool {
+	return strings.HasPrefix(name, "x-") || strings.HasPrefix(name, "y-")
+}
+
 // TemplateAttribute is a Template-Attribute Object Structure
 type TemplateAttribute struct {
 	Tag `kmip:"TEMPLATE_ATTRIBUTE"`
@@ -65,6 +299,55 @@ type TemplateAttribute struct {
 	Attributes Attributes `kmip:"ATTRIBUTE"`
 }
 
+// NewTemplateAttribute creates empty template attribute to be filled with With* methods
+func NewTemplateAttribute() *TemplateAttribute {
+	return &TemplateAttribute{}
+}
+
+// AESKeyTemplate builds template for AES key with encrypt and decrypt usage
+//
+// Length is key length in bits (e.g. 256).
+func AESKeyTemplate(length int32) *TemplateAttribute {
+	return NewTemplateAttribute().
+		WithAlgorithm(CRYPTO_AES, length).
+		WithUsageMask(CRYPTO_USAGE_MASK_ENCRYPT | CRYPTO_USAGE_MASK_DECRYPT)
+}
+
+// RSAKeyPairTemplates builds templates for RSA key pair: private key can sign,
+// public key can verify
+//
+// Length is modulus length in bits (e.g. 2048). Templates could be used as is in CreateKeyPairRequest.
+func RSAKeyPairTemplates(length int32) (common, private, public *TemplateAttribute) {
+	common = NewTemplateAttribute().WithAlgorithm(CRYPTO_RSA, length)
+	private = NewTemplateAttribute().WithUsageMask(CRYPTO_USAGE_MASK_SIGN)
+	public = NewTemplateAttribute().WithUsageMask(CRYPTO_USAGE_MASK_VERIFY)
+
+	return
+}
+
+// With sets attribute value (see Attributes.Set)
+func (t *TemplateAttribute) With(name string, val interface{}) *TemplateAttribute {
+	t.Attributes.Set(name, val)
+
+	return t
+}
+
+// WithName sets Name attribute as uninterpreted text string
+func (t *TemplateAttribute) WithName(name string) *TemplateAttribute {
+	return t.With(ATTRIBUTE_NAME_NAME, Name{Value: name, Type: NAME_TYPE_UNINTERPRETED_TEXT_STRING})
+}
+
+// WithAlgorithm sets Cryptographic Algorithm and Cryptographic Length attributes
+func (t *TemplateAttribute) WithAlgorithm(algorithm Enum, length int32) *TemplateAttribute {
+	return t.With(ATTRIBUTE_NAME_CRYPTOGRAPHIC_ALGORITHM, algorithm).
+		With(ATTRIBUTE_NAME_CRYPTOGRAPHIC_LENGTH, length)
+}
+
+// WithUsageMask sets Cryptographic Usage Mask attribute (CRYPTO_USAGE_MASK_* values combined with |)
+func (t *TemplateAttribute) WithUsageMask(mask UsageMask) *TemplateAttribute {
+	return t.With(ATTRIBUTE_NAME_CRYPTOGRAPHIC_USAGE_MASK, mask)
+}
+
 // Name is a Name Attribute Structure
 type Name struct {
 	Tag `kmip:"NAME"`
@@ -81,3 +364,100 @@ type Digest struct {
 	DigestValue      []byte `kmip:"DIGEST_VALUE"`
 	KeyFormatType    Enum   `kmip:"KEY_FORMAT_TYPE"`
 }
+
+// Link is a Link Attribute Structure
+type Link struct {
+	Tag `kmip:"LINK"`
+
+	LinkType               Enum   `kmip:"LINK_TYPE,required"`
+	LinkedObjectIdentifier string `kmip:"LINKED_OBJECT_IDENTIFIER,required"`
+}
+
+// ApplicationSpecificInformation is an Application Specific Information Attribute Structure
+type ApplicationSpecificInformation struct {
+	Tag `kmip:"APPLICATION_SPECIFIC_INFORMATION"`
+
+	ApplicationNamespace string `kmip:"APPLICATION_NAMESPACE,required"`
+	ApplicationData      string `kmip:"APPLICATION_DATA"`
+}
+
+// UsageLimits is a Usage Limits Attribute Structure
+type UsageLimits struct {
+	Tag `kmip:"USAGE_LIMITS"`
+
+	UsageLimitsTotal int64 `kmip:"USAGE_LIMITS_TOTAL,required"`
+	UsageLimitsCount int64 `kmip:"USAGE_LIMITS_COUNT"`
+	UsageLimitsUnit  Enum  `kmip:"USAGE_LIMITS_UNIT,required"`
+}
+
+// CryptographicDomainParameters is a Cryptographic Domain Parameters Attribute Structure
+type CryptographicDomainParameters struct {
+	Tag `kmip:"CRYPTOGRAPHIC_DOMAIN_PARAMETERS"`
+
+	Qlength          int32 `kmip:"QLENGTH"`
+	RecommendedCurve Enum  `kmip:"RECOMMENDED_CURVE"`
+}
+
+// X509CertificateIdentifier is a X.509 Certificate Identifier Attribute Structure
+type X509CertificateIdentifier struct {
+	Tag `kmip:"X_509_CERTIFICATE_IDENTIFIER"`
+
+	IssuerDistinguishedName []byte `kmip:"ISSUER_DISTINGUISHED_NAME,required"`
+	CertificateSerialNumber []byte `kmip:"CERTIFICATE_SERIAL_NUMBER,required"`
+}
+
+// X509CertificateSubject is a X.509 Certificate Subject Attribute Structure
+type X509CertificateSubject struct {
+	Tag `kmip:"X_509_CERTIFICATE_SUBJECT"`
+
+	SubjectDistinguishedName []byte   `kmip:"SUBJECT_DISTINGUISHED_NAME,required"`
+	SubjectAlternativeNames  [][]byte `kmip:"SUBJECT_ALTERNATIVE_NAME"`
+}
+
+// X509CertificateIssuer is a X.509 Certificate Issuer Attribute Structure
+type X509CertificateIssuer struct {
+	Tag `kmip:"X_509_CERTIFICATE_ISSUER"`
+
+	IssuerDistinguishedName []byte   `kmip:"ISSUER_DISTINGUISHED_NAME,required"`
+	IssuerAlternativeNames  [][]byte `kmip:"ISSUER_ALTERNATIVE_NAME"`
+}
+
+// CertificateIdentifier is a Certificate Identifier Attribute Structure (deprecated since KMIP 1.1)
+type CertificateIdentifier struct {
+	Tag `kmip:"CERTIFICATE_IDENTIFIER"`
+
+	Issuer       string `kmip:"ISSUER,required"`
+	SerialNumber string `kmip:"SERIAL_NUMBER"`
+}
+
+// CertificateSubject is a Certificate Subject Attribute Structure (deprecated since KMIP 1.1)
+type CertificateSubject struct {
+	Tag `kmip:"CERTIFICATE_SUBJECT"`
+
+	CertificateSubjectDistinguishedName string   `kmip:"CERTIFICATE_SUBJECT_DISTINGUISHED_NAME,required"`
+	CertificateSubjectAlternativeNames  []string `kmip:"CERTIFICATE_SUBJECT_ALTERNATIVE_NAME"`
+}
+
+// CertificateIssuer is a Certificate Issuer Attribute Structure (deprecated since KMIP 1.1)
+type CertificateIssuer struct {
+	Tag `kmip:"CERTIFICATE_ISSUER"`
+
+	CertificateIssuerDistinguishedName string   `kmip:"CERTIFICATE_ISSUER_DISTINGUISHED_NAME,required"`
+	CertificateIssuerAlternativeNames  []string `kmip:"CERTIFICATE_ISSUER_ALTERNATIVE_NAME"`
+}
+
+// AlternativeName is an Alternative Name Attribute Structure
+type AlternativeName struct {
+	Tag `kmip:"ALTERNATIVE_NAME"`
+
+	AlternativeNameValue string `kmip:"ALTERNATIVE_NAME_VALUE,required"`
+	AlternativeNameType  Enum   `kmip:"ALTERNATIVE_NAME_TYPE,required"`
+}
+
+// KeyValueLocation is a Key Value Location Attribute Structure
+type KeyValueLocation struct {
+	Tag `kmip:"KEY_VALUE_LOCATION"`
+
+	KeyValueLocationValue string `kmip:"KEY_VALUE_LOCATION_VALUE,required"`
+	KeyValueLocationType  Enum   `kmip:"KEY_VALUE_LOCATION_TYPE,required"`
+}
@@ -0,0 +1,316 @@
+package kmip
+
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AttributeSuite struct {
+	suite.Suite
+}
+
+func (s *AttributeSuite) roundTrip(attrs Attributes) Attributes {
+	data, err := Marshal(&GetAttributesResponse{UniqueIdentifier: "1", Attributes: attrs})
+	s.Require().NoError(err)
+
+	var resp GetAttributesResponse
+	s.Require().NoError(NewDecoder(bytes.NewReader(data)).Decode(&resp))
+
+	return resp.Attributes
+}
+
+func (s *AttributeSuite) TestCatalog() {
+	date := time.Unix(1500000000, 0)
+
+	attrs := Attributes{
+		{Name: ATTRIBUTE_NAME_UNIQUE_IDENTIFIER, Value: "1"},
+		{Name: ATTRIBUTE_NAME_NAME, Value: Name{Value: "key", Type: NAME_TYPE_UNINTERPRETED_TEXT_STRING}},
+		{Name: ATTRIBUTE_NAME_OBJECT_TYPE, Value: OBJECT_TYPE_SYMMETRIC_KEY},
+		{Name: ATTRIBUTE_NAME_CRYPTOGRAPHIC_ALGORITHM, Value: CRYPTO_AES},
+		{Name: ATTRIBUTE_NAME_CRYPTOGRAPHIC_LENGTH, Value: int32(256)},
+		{Name: ATTRIBUTE_NAME_CRYPTOGRAPHIC_PARAMETERS, Value: CryptoParams{BlockCipherMode: BLOCK_MODE_GCM, TagLength: 16}},
+		{Name: ATTRIBUTE_NAME_CRYPTOGRAPHIC_DOMAIN_PARAMETERS, Value: CryptographicDomainParameters{Qlength: 256}},
+		{Name: ATTRIBUTE_NAME_CERTIFICATE_TYPE, Value: Enum(1)},
+		{Name: ATTRIBUTE_NAME_CERTIFICATE_LENGTH, Value: int32(1024)},
+		{Name: ATTRIBUTE_NAME_X_509_CERTIFICATE_IDENTIFIER, Value: X509CertificateIdentifier{IssuerDistinguishedName: []byte("CN=CA"), CertificateSerialNumber: []byte{0x01}}},
+		{Name: ATTRIBUTE_NAME_X_509_CERTIFICATE_SUBJECT, Value: X509CertificateSubject{SubjectDistinguishedName: []byte("CN=leaf"), SubjectAlternativeNames: [][]byte{[]byte("a"), []byte("b")}}},
+		{Name: ATTRIBUTE_NAME_X_509_CERTIFICATE_ISSUER, Value: X509CertificateIssuer{IssuerDistinguishedName: []byte("CN=CA")}},
+		{Name: ATTRIBUTE_NAME_CERTIFICATE_IDENTIFIER, Value: CertificateIdentifier{Issuer: "CN=CA", SerialNumber: "1"}},
+		{Name: ATTRIBUTE_NAME_CERTIFICATE_SUBJECT, Value: CertificateSubject{CertificateSubjectDistinguishedName: "CN=leaf", CertificateSubjectAlternativeNames: []string{"a"}}},
+		{Name: ATTRIBUTE_NAME_CERTIFICATE_ISSUER, Value: CertificateIssuer{CertificateIssuerDistinguishedName: "CN=CA"}},
+		{Name: ATTRIBUTE_NAME_DIGITAL_SIGNATURE_ALGORITHM, Value: Enum(5)},
+		{Name: ATTRIBUTE_NAME_DIGEST, Value: Digest{HashingAlgorithm: HASH_SHA256, DigestValue: []byte{0xde, 0xad}}},
+		{Name: ATTRIBUTE_NAME_OPERATION_POLICY_NAME, Value: "default"},
+		{Name: ATTRIBUTE_NAME_CRYPTOGRAPHIC_USAGE_MASK, Value: CRYPTO_USAGE_MASK_ENCRYPT | CRYPTO_USAGE_MASK_DECRYPT},
+		{Name: ATTRIBUTE_NAME_LEASE_TIME, Value: time.Hour},
+		{Name: ATTRIBUTE_NAME_USAGE_LIMITS, Value: UsageLimits{UsageLimitsTotal: 1000, UsageLimitsCount: 10, UsageLimitsUnit: USAGE_LIMITS_UNIT_OBJECT}},
+		{Name: ATTRIBUTE_NAME_STATE, Value: STATE_ACTIVE},
+		{Name: ATTRIBUTE_NAME_INITIAL_DATE, Value: date},
+		{Name: ATTRIBUTE_NAME_ACTIVATION_DATE, Value: date},
+		{Name: ATTRIBUTE_NAME_PROCESS_START_DATE, Value: date},
+		{Name: ATTRIBUTE_NAME_PROTECT_STOP_DATE, Value: date},
+		{Name: ATTRIBUTE_NAME_DEACTIVATION_DATE, Value: date},
+		{Name: ATTRIBUTE_NAME_DESTROY_DATE, Value: date},
+		{Name: ATTRIBUTE_NAME_COMPROMISE_OCCURRENCE_DATE, Value: date},
+		{Name: ATTRIBUTE_NAME_COMPROMISE_DATE, Value: date},
+		{Name: ATTRIBUTE_NAME_REVOCATION_REASON, Value: RevocationReason{RevocationReasonCode: REVOCATION_REASON_SUPERSEDED, RevocationMessage: "rotated"}},
+		{Name: ATTRIBUTE_NAME_ARCHIVE_DATE, Value: date},
+		{Name: ATTRIBUTE_NAME_OBJECT_GROUP, Value: "group"},
+		{Name: ATTRIBUTE_NAME_FRESH, Value: true},
+		{Name: ATTRIBUTE_NAME_LINK, Value: Link{LinkType: LINK_TYPE_PUBLIC_KEY, LinkedObjectIdentifier: "2"}},
+		{Name: ATTRIBUTE_NAME_LINK, Index: 1, Value: Link{LinkType: LINK_TYPE_CERTIFICATE, LinkedObjectIdentifier: "3"}},
+		{Name: ATTRIBUTE_NAME_APPLICATION_SPECIFIC_INFORMATION, Value: ApplicationSpecificInformation{ApplicationNamespace: "ssl", ApplicationData: "example.com"}},
+		{Name: ATTRIBUTE_NAME_CONTACT_INFORMATION, Value: "admin@example.com"},
+		{Name: ATTRIBUTE_NAME_LAST_CHANGE_DATE, Value: date},
+		{Name: ATTRIBUTE_NAME_ALTERNATIVE_NAME, Value: AlternativeName{AlternativeNameValue: "alt", AlternativeNameType: Enum(1)}},
+		{Name: ATTRIBUTE_NAME_KEY_VALUE_PRESENT, Value: true},
+		{Name: ATTRIBUTE_NAME_KEY_VALUE_LOCATION, Value: KeyValueLocation{KeyValueLocationValue: "hsm", KeyValueLocationType: Enum(1)}},
+		{Name: ATTRIBUTE_NAME_ORIGINAL_CREATION_DATE, Value: date},
+	}
+
+	s.Assert().Equal(attrs, s.roundTrip(attrs))
+}
+
+func (s *AttributeSuite) TestUnknownAttribute() {
+	value := TTLV{
+		Tag:  ATTRIBUTE_VALUE,
+		Type: STRUCTURE,
+		Children: []TTLV{
+			{Tag: NAME_VALUE, Type: TEXT_STRING, Value: "foo"},
+			{Tag: CRYPTOGRAPHIC_LENGTH, Type: INTEGER, Value: int32(128)},
+		},
+	}
+
+	attrs := Attributes{
+		{Name: ATTRIBUTE_NAME_CUSTOM_ATTRIBUTE, Value: value},
+		{Name: "Some Future Attribute", Value: TTLV{Tag: ATTRIBUTE_VALUE, Type: LONG_INTEGER, Value: int64(42)}},
+		{Name: "Some Future Structure Attribute", Value: value},
+		// unregistered custom attribute structure is decoded by wire type with the same representation
+		{Name: "x-unregistered", Value: value},
+	}
+
+	s.Assert().Equal(attrs, s.roundTrip(attrs))
+}
+
+func (s *AttributeSuite) TestCustomAttributes() {
+	type costCenter string
+
+	var attrs Attributes
+
+	s.Require().NoError(attrs.SetCustom("x-owner-team", "platform"))
+	s.Require().NoError(attrs.SetCustom("x-cost-center", costCenter("cc-42")))
+	s.Require().NoError(attrs.SetCustom("x-priority", int32(3)))
+	s.Require().NoError(attrs.SetCustom("x-quota", int64(1)<<40))
+	s.Require().NoError(attrs.SetCustom("x-serial", big.NewInt(-12345)))
+	s.Require().NoError(attrs.SetCustom("x-algorithm", CRYPTO_AES))
+	s.Require().NoError(attrs.SetCustom("x-exportable", true))
+	s.Require().NoError(attrs.SetCustom("x-fingerprint", []byte{0xca, 0xfe}))
+	s.Require().NoError(attrs.SetCustom("y-reviewed", time.Unix(1500000000, 0)))
+	s.Require().NoError(attrs.SetCustom("y-rotation", 24*time.Hour))
+	s.Require().NoError(attrs.SetCustom("x-name", Name{Value: "key", Type: NAME_TYPE_UNINTERPRETED_TEXT_STRING}))
+
+	// replaces existing value
+	s.Require().NoError(attrs.SetCustom("x-priority", int32(5)))
+	s.Require().Len(attrs, 11)
+
+	s.Assert().EqualError(attrs.SetCustom("Object Group", "foo"), "attribute name \"Object Group\" is not a custom attribute name")
+
+	decoded := s.roundTrip(attrs)
+
+	for _, tc := range []struct {
+		name     string
+		expected interface{}
+	}{
+		{"x-owner-team", "platform"},
+		{"x-cost-center", "cc-42"},
+		{"x-priority", int32(5)},
+		{"x-quota", int64(1) << 40},
+		{"x-serial", big.NewInt(-12345)},
+		{"x-algorithm", CRYPTO_AES},
+		{"x-exportable", true},
+		{"x-fingerprint", []byte{0xca, 0xfe}},
+		{"y-reviewed", time.Unix(1500000000, 0)},
+		{"y-rotation", 24 * time.Hour},
+		{"x-name", TTLV{
+			Tag:  ATTRIBUTE_VALUE,
+			Type: STRUCTURE,
+			Children: []TTLV{
+				{Tag: NAME_VALUE, Type: TEXT_STRING, Value: "key"},
+				{Tag: NAME_TYPE, Type: ENUMERATION, Value: NAME_TYPE_UNINTERPRETED_TEXT_STRING},
+			},
+		}},
+	} {
+		v, ok := decoded.GetCustom(tc.name)
+		s.Assert().True(ok, tc.name)
+		s.Assert().Equal(tc.expected, v, tc.name)
+	}
+
+	_, ok := decoded.GetCustom("x-missing")
+	s.Assert().False(ok)
+
+	_, ok = decoded.GetCustom(ATTRIBUTE_NAME_NAME)
+	s.Assert().False(ok)
+}
+
+func (s *AttributeSuite) TestAccessors() {
+	date := time.Unix(1500000000, 0)
+
+	attrs := s.roundTrip(Attributes{
+		{Name: ATTRIBUTE_NAME_CRYPTOGRAPHIC_ALGORITHM, Value: CRYPTO_AES},
+		{Name: ATTRIBUTE_NAME_CRYPTOGRAPHIC_LENGTH, Value: int32(256)},
+		{Name: ATTRIBUTE_NAME_OBJECT_GROUP, Value: "group"},
+		{Name: ATTRIBUTE_NAME_ACTIVATION_DATE, Value: date},
+		{Name: ATTRIBUTE_NAME_NAME, Value: Name{Value: "key", Type: NAME_TYPE_UNINTERPRETED_TEXT_STRING}},
+		{Name: ATTRIBUTE_NAME_LINK, Value: Link{LinkType: LINK_TYPE_PUBLIC_KEY, LinkedObjectIdentifier: "2"}},
+		{Name: ATTRIBUTE_NAME_LINK, Index: 1, Value: Link{LinkType: LINK_TYPE_CERTIFICATE, LinkedObjectIdentifier: "3"}},
+	})
+
+	alg, ok := attrs.GetEnum(ATTRIBUTE_NAME_CRYPTOGRAPHIC_ALGORITHM)
+	s.Assert().True(ok)
+	s.Assert().Equal(CRYPTO_AES, alg)
+
+	length, ok := attrs.GetInt32(ATTRIBUTE_NAME_CRYPTOGRAPHIC_LENGTH)
+	s.Assert().True(ok)
+	s.Assert().EqualValues(256, length)
+
+	group, ok := attrs.GetString(ATTRIBUTE_NAME_OBJECT_GROUP)
+	s.Assert().True(ok)
+	s.Assert().Equal("group", group)
+
+	activation, ok := attrs.GetTime(ATTRIBUTE_NAME_ACTIVATION_DATE)
+	s.Assert().True(ok)
+	s.Assert().Equal(date, activation)
+
+	name, ok := attrs.GetName()
+	s.Assert().True(ok)
+	s.Assert().Equal("key", name.Value)
+
+	// wrong type or missing attribute
+	_, ok = attrs.GetInt32(ATTRIBUTE_NAME_CRYPTOGRAPHIC_ALGORITHM)
+	s.Assert().False(ok)
+	_, ok = attrs.GetTime(ATTRIBUTE_NAME_DESTROY_DATE)
+	s.Assert().False(ok)
+	_, ok = Attributes{}.GetName()
+	s.Assert().False(ok)
+
+	s.Assert().Equal([]interface{}{
+		Link{LinkType: LINK_TYPE_PUBLIC_KEY, LinkedObjectIdentifier: "2"},
+		Link{LinkType: LINK_TYPE_CERTIFICATE, LinkedObjectIdentifier: "3"},
+	}, attrs.GetAll(ATTRIBUTE_NAME_LINK))
+	s.Assert().Nil(attrs.GetAll(ATTRIBUTE_NAME_DIGEST))
+
+	link, ok := attrs.GetIndexed(ATTRIBUTE_NAME_LINK, 1)
+	s.Assert().True(ok)
+	s.Assert().Equal(Link{LinkType: LINK_TYPE_CERTIFICATE, LinkedObjectIdentifier: "3"}, link)
+
+	_, ok = attrs.GetIndexed(ATTRIBUTE_NAME_LINK, 2)
+	s.Assert().False(ok)
+}
+
+func (s *AttributeSuite) TestUsageMask() {
+	mask := CRYPTO_USAGE_MASK_ENCRYPT | CRYPTO_USAGE_MASK_DECRYPT
+
+	s.Assert().True(mask.Has(CRYPTO_USAGE_MASK_ENCRYPT))
+	s.Assert().True(mask.Has(CRYPTO_USAGE_MASK_ENCRYPT | CRYPTO_USAGE_MASK_DECRYPT))
+	s.Assert().False(mask.Has(CRYPTO_USAGE_MASK_ENCRYPT | CRYPTO_USAGE_MASK_WRAP_KEY))
+
+	mask.Set(CRYPTO_USAGE_MASK_WRAP_KEY)
+	s.Assert().Equal("Encrypt|Decrypt|WrapKey", mask.String())
+
+	mask.Clear(CRYPTO_USAGE_MASK_ENCRYPT | CRYPTO_USAGE_MASK_SIGN)
+	s.Assert().Equal("Decrypt|WrapKey", mask.String())
+
+	s.Assert().Equal("0", UsageMask(0).String())
+	s.Assert().Equal("Sign|0x00100000", (CRYPTO_USAGE_MASK_SIGN | 0x100000).String())
+
+	// decoded as UsageMask, int32 is accepted while encoding
+	attrs := s.roundTrip(Attributes{{Name: ATTRIBUTE_NAME_CRYPTOGRAPHIC_USAGE_MASK, Value: int32(12)}})
+	s.Assert().Equal(Attributes{{Name: ATTRIBUTE_NAME_CRYPTOGRAPHIC_USAGE_MASK, Value: UsageMask(12)}}, attrs)
+
+	mask, ok := attrs.GetUsageMask()
+	s.Assert().True(ok)
+	s.Assert().Equal(CRYPTO_USAGE_MASK_ENCRYPT|CRYPTO_USAGE_MASK_DECRYPT, mask)
+
+	mask, ok = Attributes{{Name: ATTRIBUTE_NAME_CRYPTOGRAPHIC_USAGE_MASK, Value: int32(1)}}.GetUsageMask()
+	s.Assert().True(ok)
+	s.Assert().Equal(CRYPTO_USAGE_MASK_SIGN, mask)
+
+	_, ok = Attributes{}.GetUsageMask()
+	s.Assert().False(ok)
+}
+
+func (s *AttributeSuite) TestMutators() {
+	var attrs Attributes
+
+	attrs.Set(ATTRIBUTE_NAME_CRYPTOGRAPHIC_LENGTH, int32(128))
+	attrs.Set(ATTRIBUTE_NAME_CRYPTOGRAPHIC_LENGTH, int32(256))
+	s.Assert().Equal(Attributes{{Name: ATTRIBUTE_NAME_CRYPTOGRAPHIC_LENGTH, Value: int32(256)}}, attrs)
+
+	s.Assert().EqualValues(0, attrs.Add(ATTRIBUTE_NAME_OBJECT_GROUP, "a"))
+	s.Assert().EqualValues(1, attrs.Add(ATTRIBUTE_NAME_OBJECT_GROUP, "b"))
+	s.Assert().EqualValues(2, attrs.Add(ATTRIBUTE_NAME_OBJECT_GROUP, "c"))
+
+	s.Assert().True(attrs.DeleteIndexed(ATTRIBUTE_NAME_OBJECT_GROUP, 1))
+	s.Assert().False(attrs.DeleteIndexed(ATTRIBUTE_NAME_OBJECT_GROUP, 1))
+
+	// indexes are not reused
+	s.Assert().EqualValues(3, attrs.Add(ATTRIBUTE_NAME_OBJECT_GROUP, "d"))
+
+	s.Assert().Equal(Attributes{
+		{Name: ATTRIBUTE_NAME_CRYPTOGRAPHIC_LENGTH, Value: int32(256)},
+		{Name: ATTRIBUTE_NAME_OBJECT_GROUP, Index: 0, Value: "a"},
+		{Name: ATTRIBUTE_NAME_OBJECT_GROUP, Index: 2, Value: "c"},
+		{Name: ATTRIBUTE_NAME_OBJECT_GROUP, Index: 3, Value: "d"},
+	}, attrs)
+
+	attrs.Set(ATTRIBUTE_NAME_OBJECT_GROUP, "z")
+	s.Assert().Equal([]interface{}{"z", "c", "d"}, attrs.GetAll(ATTRIBUTE_NAME_OBJECT_GROUP))
+
+	attrs.Delete(ATTRIBUTE_NAME_OBJECT_GROUP)
+	s.Assert().Equal(Attributes{{Name: ATTRIBUTE_NAME_CRYPTOGRAPHIC_LENGTH, Value: int32(256)}}, attrs)
+}
+
+func (s *AttributeSuite) TestTemplateBuilder() {
+	tmpl := AESKeyTemplate(256).WithName("my-key")
+
+	s.Assert().Equal(Attributes{
+		{Name: ATTRIBUTE_NAME_CRYPTOGRAPHIC_ALGORITHM, Value: CRYPTO_AES},
+		{Name: ATTRIBUTE_NAME_CRYPTOGRAPHIC_LENGTH, Value: int32(256)},
+		{Name: ATTRIBUTE_NAME_CRYPTOGRAPHIC_USAGE_MASK, Value: CRYPTO_USAGE_MASK_ENCRYPT | CRYPTO_USAGE_MASK_DECRYPT},
+		{Name: ATTRIBUTE_NAME_NAME, Value: Name{Value: "my-key", Type: NAME_TYPE_UNINTERPRETED_TEXT_STRING}},
+	}, tmpl.Attributes)
+
+	common, private, public := RSAKeyPairTemplates(2048)
+
+	s.Assert().Equal(Attributes{
+		{Name: ATTRIBUTE_NAME_CRYPTOGRAPHIC_ALGORITHM, Value: CRYPTO_RSA},
+		{Name: ATTRIBUTE_NAME_CRYPTOGRAPHIC_LENGTH, Value: int32(2048)},
+	}, common.Attributes)
+	s.Assert().Equal(Attributes{{Name: ATTRIBUTE_NAME_CRYPTOGRAPHIC_USAGE_MASK, Value: CRYPTO_USAGE_MASK_SIGN}}, private.Attributes)
+	s.Assert().Equal(Attributes{{Name: ATTRIBUTE_NAME_CRYPTOGRAPHIC_USAGE_MASK, Value: CRYPTO_USAGE_MASK_VERIFY}}, public.Attributes)
+
+	req := CreateKeyPairRequest{
+		CommonTemplateAttribute:     *common,
+		PrivateKeyTemplateAttribute: *private,
+		PublicKeyTemplateAttribute:  *public.With(ATTRIBUTE_NAME_OBJECT_GROUP, "rsa"),
+	}
+
+	data, err := Marshal(&req)
+	s.Require().NoError(err)
+
+	var req2 CreateKeyPairRequest
+	s.Require().NoError(NewDecoder(bytes.NewReader(data)).Decode(&req2))
+	s.Assert().Equal(req, req2)
+}
+
+func TestAttributeSuite(t *testing.T) {
+	suite.Run(t, new(AttributeSuite))
+}
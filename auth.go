@@ -14,15 +14,22 @@ type Authentication struct {
 	CredentialValue interface{} `kmip:"CREDENTIAL_VALUE,required"`
 }
 
+func init() {
+	RegisterCredentialType(CREDENTIAL_TYPE_USERNAME_AND_PASSWORD, &CredentialUsernamePassword{})
+}
+
 // BuildFieldValue builds value for CredentialValue based on CredentialType
+//
+// Credential types are looked up in the registry, see RegisterCredentialType.
 func (a *Authentication) BuildFieldValue(name string) (v interface{}, err error) {
-	switch a.CredentialType {
-	case CREDENTIAL_TYPE_USERNAME_AND_PASSWORD:
-		v = &CredentialUsernamePassword{}
-	default:
+	typ, ok := credentialTypeRegistry[a.CredentialType]
+	if !ok {
 		err = errors.Errorf("unsupported credential type: %v", a.CredentialType)
+		return
 	}
 
+	v = newValue(typ)
+
 	return
 }
 
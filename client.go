@@ -29,6 +29,10 @@ type Client struct {
 	// Network timeouts
 	ReadTimeout, WriteTimeout time.Duration
 
+	// Relaxed enables relaxed decoding of responses (see Decoder), which
+	// tolerates servers sending fields in different order or unknown fields
+	Relaxed bool
+
 	conn *tls.Conn
 	e    *Encoder
 	d    *Decoder
@@ -61,6 +65,7 @@ func (c *Client) Connect() error {
 
 	c.e = NewEncoder(c.conn)
 	c.d = NewDecoder(c.conn)
+	c.d.Relaxed = c.Relaxed
 
 	return nil
 }
@@ -152,16 +157,17 @@ func (c *Client) Send(operation Enum, req interface{}) (resp interface{}, err er
 		return
 	}
 
-	if response.BatchItems[0].Operation != operation {
-		err = errors.Errorf("unexpected response operation: %d", response.BatchItems[0].Operation)
+	// failure is reported even if operation is not set: server might fail to decode the request
+	if response.BatchItems[0].ResultStatus != RESULT_STATUS_SUCCESS {
+		err = wrapError(errors.New(response.BatchItems[0].ResultMessage), response.BatchItems[0].ResultReason)
 		return
 	}
 
-	if response.BatchItems[0].ResultStatus == RESULT_STATUS_SUCCESS {
-		resp = response.BatchItems[0].ResponsePayload
+	if response.BatchItems[0].Operation != operation {
+		err = errors.Errorf("unexpected response operation: %d", response.BatchItems[0].Operation)
 		return
 	}
 
-	err = wrapError(errors.New(response.BatchItems[0].ResultMessage), response.BatchItems[0].ResultReason)
+	resp = response.BatchItems[0].ResponsePayload
 	return
 }
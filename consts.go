@@ -4,6 +4,8 @@ package kmip
  * License, v. 2.0. If a copy of the MPL was not distributed with this
  * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
 
+//go:generate go run gen.go
+
 // KMIP Tags
 const (
 	// Internal
@@ -750,27 +752,28 @@ const (
 	REVOCATION_REASON_PRIVILEGE_WITHDRAWN    Enum = 0x0000007
 )
 
+// KMIP Cryptographic Usage Mask bits
 const (
-	CRYPTO_USAGE_MASK_SIGN                Enum = 0x00000001
-	CRYPTO_USAGE_MASK_VERIFY              Enum = 0x00000002
-	CRYPTO_USAGE_MASK_ENCRYPT             Enum = 0x00000004
-	CRYPTO_USAGE_MASK_DECRYPT             Enum = 0x00000008
-	CRYPTO_USAGE_MASK_WRAP_KEY            Enum = 0x00000010
-	CRYPTO_USAGE_MASK_UNWRAP_KEY          Enum = 0x00000020
-	CRYPTO_USAGE_MASK_EXPORT              Enum = 0x00000040
-	CRYPTO_USAGE_MASK_MAC_GENERATE        Enum = 0x00000080
-	CRYPTO_USAGE_MASK_MAC_VERIFY          Enum = 0x00000100
-	CRYPTO_USAGE_MASK_DERIVE_KEY          Enum = 0x00000200
-	CRYPTO_USAGE_MASK_CONTENT_COMMITMENT  Enum = 0x00000400
-	CRYPTO_USAGE_MASK_KEY_AGREEMENT       Enum = 0x00000800
-	CRYPTO_USAGE_MASK_CERTIFICATE_SIGN    Enum = 0x00001000
-	CRYPTO_USAGE_MASK_CRL_SIGN            Enum = 0x00002000
-	CRYPTO_USAGE_MASK_GENERATE_CRYPTOGRAM Enum = 0x00004000
-	CRYPTO_USAGE_MASK_VALIDATE_CRYPTOGRAM Enum = 0x00008000
-	CRYPTO_USAGE_MASK_TRANSLATE_ENCRYPT   Enum = 0x00010000
-	CRYPTO_USAGE_MASK_TRANSLATE_DECRYPT   Enum = 0x00020000
-	CRYPTO_USAGE_MASK_TRANSLATE_WRAP      Enum = 0x00040000
-	CRYPTO_USAGE_MASK_TRANSLATE_UNWRAP    Enum = 0x00080000
+	CRYPTO_USAGE_MASK_SIGN                UsageMask = 0x00000001
+	CRYPTO_USAGE_MASK_VERIFY              UsageMask = 0x00000002
+	CRYPTO_USAGE_MASK_ENCRYPT             UsageMask = 0x00000004
+	CRYPTO_USAGE_MASK_DECRYPT             UsageMask = 0x00000008
+	CRYPTO_USAGE_MASK_WRAP_KEY            UsageMask = 0x00000010
+	CRYPTO_USAGE_MASK_UNWRAP_KEY          UsageMask = 0x00000020
+	CRYPTO_USAGE_MASK_EXPORT              UsageMask = 0x00000040
+	CRYPTO_USAGE_MASK_MAC_GENERATE        UsageMask = 0x00000080
+	CRYPTO_USAGE_MASK_MAC_VERIFY          UsageMask = 0x00000100
+	CRYPTO_USAGE_MASK_DERIVE_KEY          UsageMask = 0x00000200
+	CRYPTO_USAGE_MASK_CONTENT_COMMITMENT  UsageMask = 0x00000400
+	CRYPTO_USAGE_MASK_KEY_AGREEMENT       UsageMask = 0x00000800
+	CRYPTO_USAGE_MASK_CERTIFICATE_SIGN    UsageMask = 0x00001000
+	CRYPTO_USAGE_MASK_CRL_SIGN            UsageMask = 0x00002000
+	CRYPTO_USAGE_MASK_GENERATE_CRYPTOGRAM UsageMask = 0x00004000
+	CRYPTO_USAGE_MASK_VALIDATE_CRYPTOGRAM UsageMask = 0x00008000
+	CRYPTO_USAGE_MASK_TRANSLATE_ENCRYPT   UsageMask = 0x00010000
+	CRYPTO_USAGE_MASK_TRANSLATE_DECRYPT   UsageMask = 0x00020000
+	CRYPTO_USAGE_MASK_TRANSLATE_WRAP      UsageMask = 0x00040000
+	CRYPTO_USAGE_MASK_TRANSLATE_UNWRAP    UsageMask = 0x00080000
 )
 
 // KMIP Block Cipher Mode Enumeration.
@@ -795,6 +798,77 @@ const (
 	BLOCK_MODE_AEAD              Enum = 0x00000012
 )
 
+// KMIP Link Types
+const (
+	LINK_TYPE_CERTIFICATE            Enum = 0x00000101
+	LINK_TYPE_PUBLIC_KEY             Enum = 0x00000102
+	LINK_TYPE_PRIVATE_KEY            Enum = 0x00000103
+	LINK_TYPE_DERIVATION_BASE_OBJECT Enum = 0x00000104
+	LINK_TYPE_DERIVED_KEY            Enum = 0x00000105
+	LINK_TYPE_REPLACEMENT_OBJECT     Enum = 0x00000106
+	LINK_TYPE_REPLACED_OBJECT        Enum = 0x00000107
+	LINK_TYPE_PARENT                 Enum = 0x00000108
+	LINK_TYPE_CHILD                  Enum = 0x00000109
+	LINK_TYPE_PREVIOUS               Enum = 0x0000010A
+	LINK_TYPE_NEXT                   Enum = 0x0000010B
+	LINK_TYPE_PKCS12_CERTIFICATE     Enum = 0x0000010C
+	LINK_TYPE_PKCS12_PASSWORD        Enum = 0x0000010D
+	LINK_TYPE_WRAPPING_KEY           Enum = 0x0000010E
+)
+
+// KMIP Usage Limits Units
+const (
+	USAGE_LIMITS_UNIT_BYTE   Enum = 0x00000001
+	USAGE_LIMITS_UNIT_OBJECT Enum = 0x00000002
+)
+
+// KMIP Certificate Types
+const (
+	CERTIFICATE_TYPE_X_509 Enum = 0x00000001
+	CERTIFICATE_TYPE_PGP   Enum = 0x00000002 // Deprecated in KMIP 1.2
+)
+
+// KMIP Secret Data Types
+const (
+	SECRET_DATA_TYPE_PASSWORD Enum = 0x00000001
+	SECRET_DATA_TYPE_SEED     Enum = 0x00000002
+)
+
+// KMIP Split Key Methods
+const (
+	SPLIT_KEY_METHOD_XOR                        Enum = 0x00000001
+	SPLIT_KEY_METHOD_POLYNOMIAL_SHARING_GF_2_16 Enum = 0x00000002
+	SPLIT_KEY_METHOD_POLYNOMIAL_SHARING_PRIME   Enum = 0x00000003
+	SPLIT_KEY_METHOD_POLYNOMIAL_SHARING_GF_2_8  Enum = 0x00000004
+)
+
+// KMIP Certificate Request Types
+const (
+	CERTIFICATE_REQUEST_TYPE_CRMF    Enum = 0x00000001
+	CERTIFICATE_REQUEST_TYPE_PKCS_10 Enum = 0x00000002
+	CERTIFICATE_REQUEST_TYPE_PEM     Enum = 0x00000003
+	CERTIFICATE_REQUEST_TYPE_PGP     Enum = 0x00000004 // Deprecated in KMIP 1.2
+)
+
+// KMIP Recommended Curves
+const (
+	RECOMMENDED_CURVE_P_192 Enum = 0x00000001
+	RECOMMENDED_CURVE_K_163 Enum = 0x00000002
+	RECOMMENDED_CURVE_B_163 Enum = 0x00000003
+	RECOMMENDED_CURVE_P_224 Enum = 0x00000004
+	RECOMMENDED_CURVE_K_233 Enum = 0x00000005
+	RECOMMENDED_CURVE_B_233 Enum = 0x00000006
+	RECOMMENDED_CURVE_P_256 Enum = 0x00000007
+	RECOMMENDED_CURVE_K_283 Enum = 0x00000008
+	RECOMMENDED_CURVE_B_283 Enum = 0x00000009
+	RECOMMENDED_CURVE_P_384 Enum = 0x0000000A
+	RECOMMENDED_CURVE_K_409 Enum = 0x0000000B
+	RECOMMENDED_CURVE_B_409 Enum = 0x0000000C
+	RECOMMENDED_CURVE_P_521 Enum = 0x0000000D
+	RECOMMENDED_CURVE_K_571 Enum = 0x0000000E
+	RECOMMENDED_CURVE_B_571 Enum = 0x0000000F
+)
+
 // KMIP Attribute Names.
 const (
 	ATTRIBUTE_NAME_UNIQUE_IDENTIFIER                = "Unique Identifier"
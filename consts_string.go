@@ -0,0 +1,787 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package kmip
+
+var tagNames = map[Tag]string{
+	ACTIVATION_DATE:                          "ACTIVATION_DATE",
+	APPLICATION_DATA:                         "APPLICATION_DATA",
+	APPLICATION_NAMESPACE:                    "APPLICATION_NAMESPACE",
+	APPLICATION_SPECIFIC_INFORMATION:         "APPLICATION_SPECIFIC_INFORMATION",
+	ARCHIVE_DATE:                             "ARCHIVE_DATE",
+	ASYNCHRONOUS_CORRELATION_VALUE:           "ASYNCHRONOUS_CORRELATION_VALUE",
+	ASYNCHRONOUS_INDICATOR:                   "ASYNCHRONOUS_INDICATOR",
+	ATTRIBUTE:                                "ATTRIBUTE",
+	ATTRIBUTE_INDEX:                          "ATTRIBUTE_INDEX",
+	ATTRIBUTE_NAME:                           "ATTRIBUTE_NAME",
+	ATTRIBUTE_VALUE:                          "ATTRIBUTE_VALUE",
+	AUTHENTICATION:                           "AUTHENTICATION",
+	BATCH_COUNT:                              "BATCH_COUNT",
+	BATCH_ERROR_CONTINUATION_OPTION:          "BATCH_ERROR_CONTINUATION_OPTION",
+	BATCH_ITEM:                               "BATCH_ITEM",
+	BATCH_ORDER_OPTION:                       "BATCH_ORDER_OPTION",
+	BLOCK_CIPHER_MODE:                        "BLOCK_CIPHER_MODE",
+	CANCELLATION_RESULT:                      "CANCELLATION_RESULT",
+	CERTIFICATE:                              "CERTIFICATE",
+	CERTIFICATE_IDENTIFIER:                   "CERTIFICATE_IDENTIFIER",
+	CERTIFICATE_ISSUER:                       "CERTIFICATE_ISSUER",
+	CERTIFICATE_ISSUER_ALTERNATIVE_NAME:      "CERTIFICATE_ISSUER_ALTERNATIVE_NAME",
+	CERTIFICATE_ISSUER_DISTINGUISHED_NAME:    "CERTIFICATE_ISSUER_DISTINGUISHED_NAME",
+	CERTIFICATE_REQUEST:                      "CERTIFICATE_REQUEST",
+	CERTIFICATE_REQUEST_TYPE:                 "CERTIFICATE_REQUEST_TYPE",
+	CERTIFICATE_SUBJECT:                      "CERTIFICATE_SUBJECT",
+	CERTIFICATE_SUBJECT_ALTERNATIVE_NAME:     "CERTIFICATE_SUBJECT_ALTERNATIVE_NAME",
+	CERTIFICATE_SUBJECT_DISTINGUISHED_NAME:   "CERTIFICATE_SUBJECT_DISTINGUISHED_NAME",
+	CERTIFICATE_TYPE:                         "CERTIFICATE_TYPE",
+	CERTIFICATE_VALUE:                        "CERTIFICATE_VALUE",
+	COMMON_TEMPLATE_ATTRIBUTE:                "COMMON_TEMPLATE_ATTRIBUTE",
+	COMPROMISE_DATE:                          "COMPROMISE_DATE",
+	COMPROMISE_OCCURRENCE_DATE:               "COMPROMISE_OCCURRENCE_DATE",
+	CONTACT_INFORMATION:                      "CONTACT_INFORMATION",
+	CREDENTIAL:                               "CREDENTIAL",
+	CREDENTIAL_TYPE:                          "CREDENTIAL_TYPE",
+	CREDENTIAL_VALUE:                         "CREDENTIAL_VALUE",
+	CRITICALITY_INDICATOR:                    "CRITICALITY_INDICATOR",
+	CRT_COEFFICIENT:                          "CRT_COEFFICIENT",
+	CRYPTOGRAPHIC_ALGORITHM:                  "CRYPTOGRAPHIC_ALGORITHM",
+	CRYPTOGRAPHIC_DOMAIN_PARAMETERS:          "CRYPTOGRAPHIC_DOMAIN_PARAMETERS",
+	CRYPTOGRAPHIC_LENGTH:                     "CRYPTOGRAPHIC_LENGTH",
+	CRYPTOGRAPHIC_PARAMETERS:                 "CRYPTOGRAPHIC_PARAMETERS",
+	CRYPTOGRAPHIC_USAGE_MASK:                 "CRYPTOGRAPHIC_USAGE_MASK",
+	CUSTOM_ATTRIBUTE:                         "CUSTOM_ATTRIBUTE",
+	D:                                        "D",
+	DEACTIVATION_DATE:                        "DEACTIVATION_DATE",
+	DERIVATION_DATA:                          "DERIVATION_DATA",
+	DERIVATION_METHOD:                        "DERIVATION_METHOD",
+	DERIVATION_PARAMETERS:                    "DERIVATION_PARAMETERS",
+	DESTROY_DATE:                             "DESTROY_DATE",
+	DIGEST:                                   "DIGEST",
+	DIGEST_VALUE:                             "DIGEST_VALUE",
+	ENCRYPTION_KEY_INFORMATION:               "ENCRYPTION_KEY_INFORMATION",
+	G:                                        "G",
+	HASHING_ALGORITHM:                        "HASHING_ALGORITHM",
+	INITIAL_DATE:                             "INITIAL_DATE",
+	INITIALIZATION_VECTOR:                    "INITIALIZATION_VECTOR",
+	ISSUER:                                   "ISSUER",
+	ITERATION_COUNT:                          "ITERATION_COUNT",
+	IV_COUNTER_NONCE:                         "IV_COUNTER_NONCE",
+	J:                                        "J",
+	KEY:                                      "KEY",
+	KEY_BLOCK:                                "KEY_BLOCK",
+	KEY_COMPRESSION_TYPE:                     "KEY_COMPRESSION_TYPE",
+	KEY_FORMAT_TYPE:                          "KEY_FORMAT_TYPE",
+	KEY_MATERIAL:                             "KEY_MATERIAL",
+	KEY_PART_IDENTIFIER:                      "KEY_PART_IDENTIFIER",
+	KEY_VALUE:                                "KEY_VALUE",
+	KEY_WRAPPING_DATA:                        "KEY_WRAPPING_DATA",
+	KEY_WRAPPING_SPECIFICATION:               "KEY_WRAPPING_SPECIFICATION",
+	LAST_CHANGE_DATE:                         "LAST_CHANGE_DATE",
+	LEASE_TIME:                               "LEASE_TIME",
+	LINK:                                     "LINK",
+	LINK_TYPE:                                "LINK_TYPE",
+	LINKED_OBJECT_IDENTIFIER:                 "LINKED_OBJECT_IDENTIFIER",
+	MAC_SIGNATURE:                            "MAC_SIGNATURE",
+	MAC_SIGNATURE_KEY_INFORMATION:            "MAC_SIGNATURE_KEY_INFORMATION",
+	MAXIMUM_ITEMS:                            "MAXIMUM_ITEMS",
+	MAXIMUM_RESPONSE_SIZE:                    "MAXIMUM_RESPONSE_SIZE",
+	MESSAGE_EXTENSION:                        "MESSAGE_EXTENSION",
+	MODULUS:                                  "MODULUS",
+	NAME:                                     "NAME",
+	NAME_TYPE:                                "NAME_TYPE",
+	NAME_VALUE:                               "NAME_VALUE",
+	OBJECT_GROUP:                             "OBJECT_GROUP",
+	OBJECT_TYPE:                              "OBJECT_TYPE",
+	OFFSET:                                   "OFFSET",
+	OPAQUE_DATA_TYPE:                         "OPAQUE_DATA_TYPE",
+	OPAQUE_DATA_VALUE:                        "OPAQUE_DATA_VALUE",
+	OPAQUE_OBJECT:                            "OPAQUE_OBJECT",
+	OPERATION:                                "OPERATION",
+	OPERATION_POLICY_NAME:                    "OPERATION_POLICY_NAME",
+	P:                                        "P",
+	PADDING_METHOD:                           "PADDING_METHOD",
+	PRIME_EXPONENT_P:                         "PRIME_EXPONENT_P",
+	PRIME_EXPONENT_Q:                         "PRIME_EXPONENT_Q",
+	PRIME_FIELD_SIZE:                         "PRIME_FIELD_SIZE",
+	PRIVATE_EXPONENT:                         "PRIVATE_EXPONENT",
+	PRIVATE_KEY:                              "PRIVATE_KEY",
+	PRIVATE_KEY_TEMPLATE_ATTRIBUTE:           "PRIVATE_KEY_TEMPLATE_ATTRIBUTE",
+	PRIVATE_KEY_UNIQUE_IDENTIFIER:            "PRIVATE_KEY_UNIQUE_IDENTIFIER",
+	PROCESS_START_DATE:                       "PROCESS_START_DATE",
+	PROTECT_STOP_DATE:                        "PROTECT_STOP_DATE",
+	PROTOCOL_VERSION:                         "PROTOCOL_VERSION",
+	PROTOCOL_VERSION_MAJOR:                   "PROTOCOL_VERSION_MAJOR",
+	PROTOCOL_VERSION_MINOR:                   "PROTOCOL_VERSION_MINOR",
+	PUBLIC_EXPONENT:                          "PUBLIC_EXPONENT",
+	PUBLIC_KEY:                               "PUBLIC_KEY",
+	PUBLIC_KEY_TEMPLATE_ATTRIBUTE:            "PUBLIC_KEY_TEMPLATE_ATTRIBUTE",
+	PUBLIC_KEY_UNIQUE_IDENTIFIER:             "PUBLIC_KEY_UNIQUE_IDENTIFIER",
+	PUT_FUNCTION:                             "PUT_FUNCTION",
+	Q:                                        "Q",
+	Q_STRING:                                 "Q_STRING",
+	QLENGTH:                                  "QLENGTH",
+	QUERY_FUNCTION:                           "QUERY_FUNCTION",
+	RECOMMENDED_CURVE:                        "RECOMMENDED_CURVE",
+	REPLACED_UNIQUE_IDENTIFIER:               "REPLACED_UNIQUE_IDENTIFIER",
+	REQUEST_HEADER:                           "REQUEST_HEADER",
+	REQUEST_MESSAGE:                          "REQUEST_MESSAGE",
+	REQUEST_PAYLOAD:                          "REQUEST_PAYLOAD",
+	RESPONSE_HEADER:                          "RESPONSE_HEADER",
+	RESPONSE_MESSAGE:                         "RESPONSE_MESSAGE",
+	RESPONSE_PAYLOAD:                         "RESPONSE_PAYLOAD",
+	RESULT_MESSAGE:                           "RESULT_MESSAGE",
+	RESULT_REASON:                            "RESULT_REASON",
+	RESULT_STATUS:                            "RESULT_STATUS",
+	REVOCATION_MESSAGE:                       "REVOCATION_MESSAGE",
+	REVOCATION_REASON:                        "REVOCATION_REASON",
+	REVOCATION_REASON_CODE:                   "REVOCATION_REASON_CODE",
+	KEY_ROLE_TYPE:                            "KEY_ROLE_TYPE",
+	SALT:                                     "SALT",
+	SECRET_DATA:                              "SECRET_DATA",
+	SECRET_DATA_TYPE:                         "SECRET_DATA_TYPE",
+	SERIAL_NUMBER:                            "SERIAL_NUMBER",
+	SERVER_INFORMATION:                       "SERVER_INFORMATION",
+	SPLIT_KEY:                                "SPLIT_KEY",
+	SPLIT_KEY_METHOD:                         "SPLIT_KEY_METHOD",
+	SPLIT_KEY_PARTS:                          "SPLIT_KEY_PARTS",
+	SPLIT_KEY_THRESHOLD:                      "SPLIT_KEY_THRESHOLD",
+	STATE:                                    "STATE",
+	STORAGE_STATUS_MASK:                      "STORAGE_STATUS_MASK",
+	SYMMETRIC_KEY:                            "SYMMETRIC_KEY",
+	TEMPLATE:                                 "TEMPLATE",
+	TEMPLATE_ATTRIBUTE:                       "TEMPLATE_ATTRIBUTE",
+	TIME_STAMP:                               "TIME_STAMP",
+	UNIQUE_BATCH_ITEM_ID:                     "UNIQUE_BATCH_ITEM_ID",
+	UNIQUE_IDENTIFIER:                        "UNIQUE_IDENTIFIER",
+	USAGE_LIMITS:                             "USAGE_LIMITS",
+	USAGE_LIMITS_COUNT:                       "USAGE_LIMITS_COUNT",
+	USAGE_LIMITS_TOTAL:                       "USAGE_LIMITS_TOTAL",
+	USAGE_LIMITS_UNIT:                        "USAGE_LIMITS_UNIT",
+	USERNAME:                                 "USERNAME",
+	VALIDITY_DATE:                            "VALIDITY_DATE",
+	VALIDITY_INDICATOR:                       "VALIDITY_INDICATOR",
+	VENDOR_EXTENSION:                         "VENDOR_EXTENSION",
+	VENDOR_IDENTIFICATION:                    "VENDOR_IDENTIFICATION",
+	WRAPPING_METHOD:                          "WRAPPING_METHOD",
+	X:                                        "X",
+	Y:                                        "Y",
+	PASSWORD:                                 "PASSWORD",
+	DEVICE_IDENTIFIER:                        "DEVICE_IDENTIFIER",
+	ENCODING_OPTION:                          "ENCODING_OPTION",
+	EXTENSION_INFORMATION:                    "EXTENSION_INFORMATION",
+	EXTENSION_NAME:                           "EXTENSION_NAME",
+	EXTENSION_TAG:                            "EXTENSION_TAG",
+	EXTENSION_TYPE:                           "EXTENSION_TYPE",
+	FRESH:                                    "FRESH",
+	MACHINE_IDENTIFIER:                       "MACHINE_IDENTIFIER",
+	MEDIA_IDENTIFIER:                         "MEDIA_IDENTIFIER",
+	NETWORK_IDENTIFIER:                       "NETWORK_IDENTIFIER",
+	OBJECT_GROUP_MEMBER:                      "OBJECT_GROUP_MEMBER",
+	CERTIFICATE_LENGTH:                       "CERTIFICATE_LENGTH",
+	DIGITAL_SIGNATURE_ALGORITHM:              "DIGITAL_SIGNATURE_ALGORITHM",
+	CERTIFICATE_SERIAL_NUMBER:                "CERTIFICATE_SERIAL_NUMBER",
+	DEVICE_SERIAL_NUMBER:                     "DEVICE_SERIAL_NUMBER",
+	ISSUER_ALTERNATIVE_NAME:                  "ISSUER_ALTERNATIVE_NAME",
+	ISSUER_DISTINGUISHED_NAME:                "ISSUER_DISTINGUISHED_NAME",
+	SUBJECT_ALTERNATIVE_NAME:                 "SUBJECT_ALTERNATIVE_NAME",
+	SUBJECT_DISTINGUISHED_NAME:               "SUBJECT_DISTINGUISHED_NAME",
+	X_509_CERTIFICATE_IDENTIFIER:             "X_509_CERTIFICATE_IDENTIFIER",
+	X_509_CERTIFICATE_ISSUER:                 "X_509_CERTIFICATE_ISSUER",
+	X_509_CERTIFICATE_SUBJECT:                "X_509_CERTIFICATE_SUBJECT",
+	KEY_VALUE_LOCATION:                       "KEY_VALUE_LOCATION",
+	KEY_VALUE_LOCATION_VALUE:                 "KEY_VALUE_LOCATION_VALUE",
+	KEY_VALUE_LOCATION_TYPE:                  "KEY_VALUE_LOCATION_TYPE",
+	KEY_VALUE_PRESENT:                        "KEY_VALUE_PRESENT",
+	ORIGINAL_CREATION_DATE:                   "ORIGINAL_CREATION_DATE",
+	PGP_KEY:                                  "PGP_KEY",
+	PGP_KEY_VERSION:                          "PGP_KEY_VERSION",
+	ALTERNATIVE_NAME:                         "ALTERNATIVE_NAME",
+	ALTERNATIVE_NAME_VALUE:                   "ALTERNATIVE_NAME_VALUE",
+	ALTERNATIVE_NAME_TYPE:                    "ALTERNATIVE_NAME_TYPE",
+	DATA:                                     "DATA",
+	SIGNATURE_DATA:                           "SIGNATURE_DATA",
+	DATA_LENGTH:                              "DATA_LENGTH",
+	RANDOM_IV:                                "RANDOM_IV",
+	MAC_DATA:                                 "MAC_DATA",
+	ATTESTATION_TYPE:                         "ATTESTATION_TYPE",
+	NONCE:                                    "NONCE",
+	NONCE_ID:                                 "NONCE_ID",
+	NONCE_VALUE:                              "NONCE_VALUE",
+	ATTESTATION_MEASUREMENT:                  "ATTESTATION_MEASUREMENT",
+	ATTESTATION_ASSERTION:                    "ATTESTATION_ASSERTION",
+	IV_LENGTH:                                "IV_LENGTH",
+	TAG_LENGTH:                               "TAG_LENGTH",
+	FIXED_FIELD_LENGTH:                       "FIXED_FIELD_LENGTH",
+	COUNTER_LENGTH:                           "COUNTER_LENGTH",
+	INITIAL_COUNTER_VALUE:                    "INITIAL_COUNTER_VALUE",
+	INVOCATION_FIELD_LENGTH:                  "INVOCATION_FIELD_LENGTH",
+	ATTESTATION_CAPABLE_INDICATOR:            "ATTESTATION_CAPABLE_INDICATOR",
+	OFFSET_ITEMS:                             "OFFSET_ITEMS",
+	LOCATED_ITEMS:                            "LOCATED_ITEMS",
+	CORRELATION_VALUE:                        "CORRELATION_VALUE",
+	INIT_INDICATOR:                           "INIT_INDICATOR",
+	FINAL_INDICATOR:                          "FINAL_INDICATOR",
+	RNG_PARAMETERS:                           "RNG_PARAMETERS",
+	RNG_ALGORITHM:                            "RNG_ALGORITHM",
+	DRBG_ALGORITHM:                           "DRBG_ALGORITHM",
+	FIPS186_VARIATION:                        "FIPS186_VARIATION",
+	PREDICTION_RESISTANCE:                    "PREDICTION_RESISTANCE",
+	RANDOM_NUMBER_GENERATOR:                  "RANDOM_NUMBER_GENERATOR",
+	VALIDATION_INFORMATION:                   "VALIDATION_INFORMATION",
+	VALIDATION_AUTHORITY_TYPE:                "VALIDATION_AUTHORITY_TYPE",
+	VALIDATION_AUTHORITY_COUNTRY:             "VALIDATION_AUTHORITY_COUNTRY",
+	VALIDATION_AUTHORITY_URI:                 "VALIDATION_AUTHORITY_URI",
+	VALIDATION_VERSION_MAJOR:                 "VALIDATION_VERSION_MAJOR",
+	VALIDATION_VERSION_MINOR:                 "VALIDATION_VERSION_MINOR",
+	VALIDATION_TYPE:                          "VALIDATION_TYPE",
+	VALIDATION_LEVEL:                         "VALIDATION_LEVEL",
+	VALIDATION_CERTIFICATE_IDENTIFIER:        "VALIDATION_CERTIFICATE_IDENTIFIER",
+	VALIDATION_CERTIFICATE_URI:               "VALIDATION_CERTIFICATE_URI",
+	VALIDATION_VENDOR_URI:                    "VALIDATION_VENDOR_URI",
+	VALIDATION_PROFILE:                       "VALIDATION_PROFILE",
+	PROFILE_INFORMATION:                      "PROFILE_INFORMATION",
+	PROFILE_NAME:                             "PROFILE_NAME",
+	SERVER_URI:                               "SERVER_URI",
+	SERVER_PORT:                              "SERVER_PORT",
+	STREAMING_CAPABILITY:                     "STREAMING_CAPABILITY",
+	ASYNCHRONOUS_CAPABILITY:                  "ASYNCHRONOUS_CAPABILITY",
+	ATTESTATION_CAPABILITY:                   "ATTESTATION_CAPABILITY",
+	UNWRAP_MODE:                              "UNWRAP_MODE",
+	DESTROY_ACTION:                           "DESTROY_ACTION",
+	SHREDDING_ALGORITHM:                      "SHREDDING_ALGORITHM",
+	RNG_MODE:                                 "RNG_MODE",
+	CLIENT_REGISTRATION_METHOD:               "CLIENT_REGISTRATION_METHOD",
+	CAPABILITY_INFORMATION:                   "CAPABILITY_INFORMATION",
+	KEY_WRAP_TYPE:                            "KEY_WRAP_TYPE",
+	BATCH_UNDO_CAPABILITY:                    "BATCH_UNDO_CAPABILITY",
+	BATCH_CONTINUE_CAPABILITY:                "BATCH_CONTINUE_CAPABILITY",
+	PKCS12_FRIENDLY_NAME:                     "PKCS12_FRIENDLY_NAME",
+	DESCRIPTION:                              "DESCRIPTION",
+	COMMENT:                                  "COMMENT",
+	AUTHENTICATED_ENCRYPTION_ADDITIONAL_DATA: "AUTHENTICATED_ENCRYPTION_ADDITIONAL_DATA",
+	AUTHENTICATED_ENCRYPTION_TAG:             "AUTHENTICATED_ENCRYPTION_TAG",
+	SALT_LENGTH:                              "SALT_LENGTH",
+	MASK_GENERATOR:                           "MASK_GENERATOR",
+	MASK_GENERATOR_HASHING_ALGORITHM:         "MASK_GENERATOR_HASHING_ALGORITHM",
+	P_SOURCE:                                 "P_SOURCE",
+	TRAILER_FIELD:                            "TRAILER_FIELD",
+	CLIENT_CORRELATION_VALUE:                 "CLIENT_CORRELATION_VALUE",
+	SERVER_CORRELATION_VALUE:                 "SERVER_CORRELATION_VALUE",
+	DIGESTED_DATA:                            "DIGESTED_DATA",
+	CERTIFICATE_SUBJECT_CN:                   "CERTIFICATE_SUBJECT_CN",
+	CERTIFICATE_SUBJECT_O:                    "CERTIFICATE_SUBJECT_O",
+	CERTIFICATE_SUBJECT_OU:                   "CERTIFICATE_SUBJECT_OU",
+	CERTIFICATE_SUBJECT_EMAIL:                "CERTIFICATE_SUBJECT_EMAIL",
+	CERTIFICATE_SUBJECT_C:                    "CERTIFICATE_SUBJECT_C",
+	CERTIFICATE_SUBJECT_ST:                   "CERTIFICATE_SUBJECT_ST",
+	CERTIFICATE_SUBJECT_L:                    "CERTIFICATE_SUBJECT_L",
+	CERTIFICATE_SUBJECT_UID:                  "CERTIFICATE_SUBJECT_UID",
+	CERTIFICATE_SUBJECT_SERIAL_NUMBER:        "CERTIFICATE_SUBJECT_SERIAL_NUMBER",
+	CERTIFICATE_SUBJECT_TITLE:                "CERTIFICATE_SUBJECT_TITLE",
+	CERTIFICATE_SUBJECT_DC:                   "CERTIFICATE_SUBJECT_DC",
+	CERTIFICATE_SUBJECT_DN_QUALIFIER:         "CERTIFICATE_SUBJECT_DN_QUALIFIER",
+	CERTIFICATE_ISSUER_CN:                    "CERTIFICATE_ISSUER_CN",
+	CERTIFICATE_ISSUER_O:                     "CERTIFICATE_ISSUER_O",
+	CERTIFICATE_ISSUER_OU:                    "CERTIFICATE_ISSUER_OU",
+	CERTIFICATE_ISSUER_EMAIL:                 "CERTIFICATE_ISSUER_EMAIL",
+	CERTIFICATE_ISSUER_C:                     "CERTIFICATE_ISSUER_C",
+	CERTIFICATE_ISSUER_ST:                    "CERTIFICATE_ISSUER_ST",
+	CERTIFICATE_ISSUER_L:                     "CERTIFICATE_ISSUER_L",
+	CERTIFICATE_ISSUER_UID:                   "CERTIFICATE_ISSUER_UID",
+	CERTIFICATE_ISSUER_SERIAL_NUMBER:         "CERTIFICATE_ISSUER_SERIAL_NUMBER",
+	CERTIFICATE_ISSUER_TITLE:                 "CERTIFICATE_ISSUER_TITLE",
+	CERTIFICATE_ISSUER_DC:                    "CERTIFICATE_ISSUER_DC",
+	CERTIFICATE_ISSUER_DN_QUALIFIER:          "CERTIFICATE_ISSUER_DN_QUALIFIER",
+	SENSITIVE:                                "SENSITIVE",
+	ALWAYS_SENSITIVE:                         "ALWAYS_SENSITIVE",
+	EXTRACTABLE:                              "EXTRACTABLE",
+	NEVER_EXTRACTABLE:                        "NEVER_EXTRACTABLE",
+	REPLACE_EXISTING:                         "REPLACE_EXISTING",
+	ATTRIBUTES:                               "ATTRIBUTES",
+	COMMON_ATTRIBUTES:                        "COMMON_ATTRIBUTES",
+	PRIVATE_KEY_ATTRIBUTES:                   "PRIVATE_KEY_ATTRIBUTES",
+	PUBLIC_KEY_ATTRIBUTES:                    "PUBLIC_KEY_ATTRIBUTES",
+	EXTENSION_ENUMERATION:                    "EXTENSION_ENUMERATION",
+	EXTENSION_ATTRIBUTE:                      "EXTENSION_ATTRIBUTE",
+	EXTENSION_PARENT_STRUCTURE_TAG:           "EXTENSION_PARENT_STRUCTURE_TAG",
+	EXTENSION_DESCRIPTION:                    "EXTENSION_DESCRIPTION",
+	SERVER_NAME:                              "SERVER_NAME",
+	SERVER_SERIAL_NUMBER:                     "SERVER_SERIAL_NUMBER",
+	SERVER_VERSION:                           "SERVER_VERSION",
+	SERVER_LOAD:                              "SERVER_LOAD",
+	PRODUCT_NAME:                             "PRODUCT_NAME",
+	BUILD_LEVEL:                              "BUILD_LEVEL",
+	BUILD_DATE:                               "BUILD_DATE",
+	CLUSTER_INFO:                             "CLUSTER_INFO",
+	ALTERNATE_FAILOVER_ENDPOINTS:             "ALTERNATE_FAILOVER_ENDPOINTS",
+	SHORT_UNIQUE_IDENTIFIER:                  "SHORT_UNIQUE_IDENTIFIER",
+	RESERVED:                                 "RESERVED",
+	TAG:                                      "TAG",
+	CERTIFICATE_REQUEST_UNIQUE_IDENTIFIER:    "CERTIFICATE_REQUEST_UNIQUE_IDENTIFIER",
+	NIST_KEY_TYPE:                            "NIST_KEY_TYPE",
+	ATTRIBUTE_REFERENCE:                      "ATTRIBUTE_REFERENCE",
+	CURRENT_ATTRIBUTE:                        "CURRENT_ATTRIBUTE",
+	NEW_ATTRIBUTE:                            "NEW_ATTRIBUTE",
+	CERTIFICATE_REQUEST_VALUE:                "CERTIFICATE_REQUEST_VALUE",
+	LOG_MESSAGE:                              "LOG_MESSAGE",
+	PROFILE_VERSION:                          "PROFILE_VERSION",
+	PROFILE_VERSION_MAJOR:                    "PROFILE_VERSION_MAJOR",
+	PROFILE_VERSION_MINOR:                    "PROFILE_VERSION_MINOR",
+	PROTECTION_LEVEL:                         "PROTECTION_LEVEL",
+	PROTECTION_PERIOD:                        "PROTECTION_PERIOD",
+	QUANTUM_SAFE:                             "QUANTUM_SAFE",
+	QUANTUM_SAFE_CAPABILITY:                  "QUANTUM_SAFE_CAPABILITY",
+	TICKET:                                   "TICKET",
+	TICKET_TYPE:                              "TICKET_TYPE",
+	TICKET_VALUE:                             "TICKET_VALUE",
+	REQUEST_COUNT:                            "REQUEST_COUNT",
+	RIGHTS:                                   "RIGHTS",
+	OBJECTS:                                  "OBJECTS",
+	OPERATIONS:                               "OPERATIONS",
+	RIGHT:                                    "RIGHT",
+	ENDPOINT_ROLE:                            "ENDPOINT_ROLE",
+	DEFAULTS_INFORMATION:                     "DEFAULTS_INFORMATION",
+	OBJECT_DEFAULTS:                          "OBJECT_DEFAULTS",
+	EPHEMERAL:                                "EPHEMERAL",
+	SERVER_HASHED_PASSWORD:                   "SERVER_HASHED_PASSWORD",
+	ONE_TIME_PASSWORD:                        "ONE_TIME_PASSWORD",
+	HASHED_PASSWORD:                          "HASHED_PASSWORD",
+	ADJUSTMENT_TYPE:                          "ADJUSTMENT_TYPE",
+	PKCS11_INTERFACE:                         "PKCS11_INTERFACE",
+	PKCS11_FUNCTION:                          "PKCS11_FUNCTION",
+	PKCS11_INPUT_PARAMETERS:                  "PKCS11_INPUT_PARAMETERS",
+	PKCS11_OUTPUT_PARAMETERS:                 "PKCS11_OUTPUT_PARAMETERS",
+	PKCS11_RETURN_CODE:                       "PKCS11_RETURN_CODE",
+	PROTECTION_STORAGE_MASK:                  "PROTECTION_STORAGE_MASK",
+	PROTECTION_STORAGE_MASKS:                 "PROTECTION_STORAGE_MASKS",
+	INTEROP_FUNCTION:                         "INTEROP_FUNCTION",
+	INTEROP_IDENTIFIER:                       "INTEROP_IDENTIFIER",
+	ADJUSTMENT_VALUE:                         "ADJUSTMENT_VALUE",
+}
+
+var enumNames = map[Tag]map[Enum]string{
+	OPERATION:                        enumNamesOperation,
+	OBJECT_TYPE:                      enumNamesObjectType,
+	STATE:                            enumNamesState,
+	KEY_FORMAT_TYPE:                  enumNamesKeyFormat,
+	KEY_WRAP_TYPE:                    enumNamesKeyWrap,
+	WRAPPING_METHOD:                  enumNamesWrappingMethod,
+	KEY_COMPRESSION_TYPE:             enumNamesKeyCompression,
+	NAME_TYPE:                        enumNamesNameType,
+	CRYPTOGRAPHIC_ALGORITHM:          enumNamesCrypto,
+	PADDING_METHOD:                   enumNamesPaddingMethod,
+	HASHING_ALGORITHM:                enumNamesHash,
+	MASK_GENERATOR_HASHING_ALGORITHM: enumNamesHash,
+	CREDENTIAL_TYPE:                  enumNamesCredentialType,
+	RESULT_STATUS:                    enumNamesResultStatus,
+	RESULT_REASON:                    enumNamesResultReason,
+	REVOCATION_REASON_CODE:           enumNamesRevocationReason,
+	BLOCK_CIPHER_MODE:                enumNamesBlockMode,
+	LINK_TYPE:                        enumNamesLinkType,
+	USAGE_LIMITS_UNIT:                enumNamesUsageLimitsUnit,
+	CERTIFICATE_TYPE:                 enumNamesCertificateType,
+	SECRET_DATA_TYPE:                 enumNamesSecretDataType,
+	SPLIT_KEY_METHOD:                 enumNamesSplitKeyMethod,
+	CERTIFICATE_REQUEST_TYPE:         enumNamesCertificateRequestType,
+	RECOMMENDED_CURVE:                enumNamesRecommendedCurve,
+}
+
+var enumNamesOperation = map[Enum]string{
+	OPERATION_CREATE:               "CREATE",
+	OPERATION_CREATE_KEY_PAIR:      "CREATE_KEY_PAIR",
+	OPERATION_REGISTER:             "REGISTER",
+	OPERATION_REKEY:                "REKEY",
+	OPERATION_DERIVE_KEY:           "DERIVE_KEY",
+	OPERATION_CERTIFY:              "CERTIFY",
+	OPERATION_RECERTIFY:            "RECERTIFY",
+	OPERATION_LOCATE:               "LOCATE",
+	OPERATION_CHECK:                "CHECK",
+	OPERATION_GET:                  "GET",
+	OPERATION_GET_ATTRIBUTES:       "GET_ATTRIBUTES",
+	OPERATION_GET_ATTRIBUTE_LIST:   "GET_ATTRIBUTE_LIST",
+	OPERATION_ADD_ATTRIBUTE:        "ADD_ATTRIBUTE",
+	OPERATION_MODIFY_ATTRIBUTE:     "MODIFY_ATTRIBUTE",
+	OPERATION_DELETE_ATTRIBUTE:     "DELETE_ATTRIBUTE",
+	OPERATION_OBTAIN_LEASE:         "OBTAIN_LEASE",
+	OPERATION_GET_USAGE_ALLOCATION: "GET_USAGE_ALLOCATION",
+	OPERATION_ACTIVATE:             "ACTIVATE",
+	OPERATION_REVOKE:               "REVOKE",
+	OPERATION_DESTROY:              "DESTROY",
+	OPERATION_ARCHIVE:              "ARCHIVE",
+	OPERATION_RECOVER:              "RECOVER",
+	OPERATION_VALIDATE:             "VALIDATE",
+	OPERATION_QUERY:                "QUERY",
+	OPERATION_CANCEL:               "CANCEL",
+	OPERATION_POLL:                 "POLL",
+	OPERATION_NOTIFY:               "NOTIFY",
+	OPERATION_PUT:                  "PUT",
+	OPERATION_REKEY_KEY_PAIR:       "REKEY_KEY_PAIR",
+	OPERATION_DISCOVER_VERSIONS:    "DISCOVER_VERSIONS",
+	OPERATION_ENCRYPT:              "ENCRYPT",
+	OPERATION_DECRYPT:              "DECRYPT",
+	OPERATION_SIGN:                 "SIGN",
+	OPERATION_SIGNATURE_VERIFY:     "SIGNATURE_VERIFY",
+	OPERATION_MAC:                  "MAC",
+	OPERATION_MAC_VERIFY:           "MAC_VERIFY",
+	OPERATION_RNG_RETRIEVE:         "RNG_RETRIEVE",
+	OPERATION_RNG_SEED:             "RNG_SEED",
+	OPERATION_HASH:                 "HASH",
+	OPERATION_CREATE_SPLIT_KEY:     "CREATE_SPLIT_KEY",
+	OPERATION_JOIN_SPLIT_KEY:       "JOIN_SPLIT_KEY",
+	OPERATION_IMPORT:               "IMPORT",
+	OPERATION_EXPORT:               "EXPORT",
+	OPERATION_LOG:                  "LOG",
+	OPERATION_LOGIN:                "LOGIN",
+	OPERATION_LOGOUT:               "LOGOUT",
+	OPERATION_DELEGATED_LOGIN:      "DELEGATED_LOGIN",
+	OPERATION_ADJUST_ATTRIBUTE:     "ADJUST_ATTRIBUTE",
+	OPERATION_SET_ATTRIBUTE:        "SET_ATTRIBUTE",
+	OPERATION_SET_ENDPOINT_ROLE:    "SET_ENDPOINT_ROLE",
+	OPERATION_PKCS_11:              "PKCS_11",
+	OPERATION_INTEROP:              "INTEROP",
+	OPERATION_REPROVISION:          "REPROVISION",
+}
+
+var enumNamesObjectType = map[Enum]string{
+	OBJECT_TYPE_CERTIFICATE:         "CERTIFICATE",
+	OBJECT_TYPE_SYMMETRIC_KEY:       "SYMMETRIC_KEY",
+	OBJECT_TYPE_PUBLIC_KEY:          "PUBLIC_KEY",
+	OBJECT_TYPE_PRIVATE_KEY:         "PRIVATE_KEY",
+	OBJECT_TYPE_SPLIT_KEY:           "SPLIT_KEY",
+	OBJECT_TYPE_TEMPLATE:            "TEMPLATE",
+	OBJECT_TYPE_SECRET_DATA:         "SECRET_DATA",
+	OBJECT_TYPE_OPAQUE_DATA:         "OPAQUE_DATA",
+	OBJECT_TYPE_PGP_KEY:             "PGP_KEY",
+	OBJECT_TYPE_CERTIFICATE_REQUEST: "CERTIFICATE_REQUEST",
+}
+
+var enumNamesState = map[Enum]string{
+	STATE_PRE_ACTIVE:            "PRE_ACTIVE",
+	STATE_ACTIVE:                "ACTIVE",
+	STATE_DEACTIVATED:           "DEACTIVATED",
+	STATE_COMPROMISED:           "COMPROMISED",
+	STATE_DESTROYED:             "DESTROYED",
+	STATE_DESTROYED_COMPROMISED: "DESTROYED_COMPROMISED",
+}
+
+var enumNamesKeyFormat = map[Enum]string{
+	KEY_FORMAT_RAW:                           "RAW",
+	KEY_FORMAT_OPAQUE:                        "OPAQUE",
+	KEY_FORMAT_PKCS_1:                        "PKCS_1",
+	KEY_FORMAT_PKCS_8:                        "PKCS_8",
+	KEY_FORMAT_X_509:                         "X_509",
+	KEY_FORMAT_EC_PRIVATE_KEY:                "EC_PRIVATE_KEY",
+	KEY_FORMAT_TRANSPARENT_SYMMETRIC_KEY:     "TRANSPARENT_SYMMETRIC_KEY",
+	KEY_FORMAT_TRANSPARENT_DSA_PRIVATE_KEY:   "TRANSPARENT_DSA_PRIVATE_KEY",
+	KEY_FORMAT_TRANSPARENT_DSA_PUBLIC_KEY:    "TRANSPARENT_DSA_PUBLIC_KEY",
+	KEY_FORMAT_TRANSPARENT_RSA_PRIVATE_KEY:   "TRANSPARENT_RSA_PRIVATE_KEY",
+	KEY_FORMAT_TRANSPARENT_RSA_PUBLIC_KEY:    "TRANSPARENT_RSA_PUBLIC_KEY",
+	KEY_FORMAT_TRANSPARENT_DH_PRIVATE_KEY:    "TRANSPARENT_DH_PRIVATE_KEY",
+	KEY_FORMAT_TRANSPARENT_DH_PUBLIC_KEY:     "TRANSPARENT_DH_PUBLIC_KEY",
+	KEY_FORMAT_TRANSPARENT_ECDSA_PRIVATE_KEY: "TRANSPARENT_ECDSA_PRIVATE_KEY",
+	KEY_FORMAT_TRANSPARENT_ECDSA_PUBLIC_KEY:  "TRANSPARENT_ECDSA_PUBLIC_KEY",
+	KEY_FORMAT_TRANSPARENT_ECDH_PRIVATE_KEY:  "TRANSPARENT_ECDH_PRIVATE_KEY",
+	KEY_FORMAT_TRANSPARENT_ECDH_PUBLIC_KEY:   "TRANSPARENT_ECDH_PUBLIC_KEY",
+	KEY_FORMAT_TRANSPARENT_ECMQV_PRIVATE_KEY: "TRANSPARENT_ECMQV_PRIVATE_KEY",
+	KEY_FORMAT_TRANSPARENT_ECMQV_PUBLIC_KEY:  "TRANSPARENT_ECMQV_PUBLIC_KEY",
+	KEY_FORMAT_TRANSPARENT_EC_PRIVATE_KEY:    "TRANSPARENT_EC_PRIVATE_KEY",
+	KEY_FORMAT_TRANSPARENT_EC_PUBLIC_KEY:     "TRANSPARENT_EC_PUBLIC_KEY",
+	KEY_FORMAT_PKCS_12:                       "PKCS_12",
+}
+
+var enumNamesKeyWrap = map[Enum]string{
+	KEY_WRAP_NOT_WRAPPED:   "NOT_WRAPPED",
+	KEY_WRAP_AS_REGISTERED: "AS_REGISTERED",
+}
+
+var enumNamesWrappingMethod = map[Enum]string{
+	WRAPPING_METHOD_ENCRYPT:               "ENCRYPT",
+	WRAPPING_METHOD_MAC_SIGN:              "MAC_SIGN",
+	WRAPPING_METHOD_ENCRYPT_THEN_MAC_SIGN: "ENCRYPT_THEN_MAC_SIGN",
+	WRAPPING_METHOD_MAC_SIGN_THEN_ENCRYPT: "MAC_SIGN_THEN_ENCRYPT",
+	WRAPPING_METHOD_TR_31:                 "TR_31",
+}
+
+var enumNamesKeyCompression = map[Enum]string{
+	KEY_COMPRESSION_EC_PUBLIC_KEY_TYPE_UNCOMPRESSED:           "EC_PUBLIC_KEY_TYPE_UNCOMPRESSED",
+	KEY_COMPRESSION_EC_PUBLIC_KEY_TYPE_X9_62_COMPRESSED_PRIME: "EC_PUBLIC_KEY_TYPE_X9_62_COMPRESSED_PRIME",
+	KEY_COMPRESSION_EC_PUBLIC_KEY_TYPE_X9_62_COMPRESSED_CHAR2: "EC_PUBLIC_KEY_TYPE_X9_62_COMPRESSED_CHAR2",
+	KEY_COMPRESSION_EC_PUBLIC_KEY_TYPE_X9_62_HYBRID:           "EC_PUBLIC_KEY_TYPE_X9_62_HYBRID",
+}
+
+var enumNamesNameType = map[Enum]string{
+	NAME_TYPE_UNINTERPRETED_TEXT_STRING: "UNINTERPRETED_TEXT_STRING",
+	NAME_TYPE_URI:                       "URI",
+}
+
+var enumNamesCrypto = map[Enum]string{
+	CRYPTO_DES:               "DES",
+	CRYPTO_TRIPLE_DES:        "TRIPLE_DES",
+	CRYPTO_AES:               "AES",
+	CRYPTO_RSA:               "RSA",
+	CRYPTO_DSA:               "DSA",
+	CRYPTO_ECDSA:             "ECDSA",
+	CRYPTO_HMAC_SHA1:         "HMAC_SHA1",
+	CRYPTO_HMAC_SHA224:       "HMAC_SHA224",
+	CRYPTO_HMAC_SHA256:       "HMAC_SHA256",
+	CRYPTO_HMAC_SHA384:       "HMAC_SHA384",
+	CRYPTO_HMAC_SHA512:       "HMAC_SHA512",
+	CRYPTO_HMAC_MD5:          "HMAC_MD5",
+	CRYPTO_DH:                "DH",
+	CRYPTO_ECDH:              "ECDH",
+	CRYPTO_ECMQV:             "ECMQV",
+	CRYPTO_BLOWFISH:          "BLOWFISH",
+	CRYPTO_CAMELLIA:          "CAMELLIA",
+	CRYPTO_CAST5:             "CAST5",
+	CRYPTO_IDEA:              "IDEA",
+	CRYPTO_MARS:              "MARS",
+	CRYPTO_RC2:               "RC2",
+	CRYPTO_RC4:               "RC4",
+	CRYPTO_RC5:               "RC5",
+	CRYPTO_SKIPJACK:          "SKIPJACK",
+	CRYPTO_TWOFISH:           "TWOFISH",
+	CRYPTO_EC:                "EC",
+	CRYPTO_ONE_TIME_PAD:      "ONE_TIME_PAD",
+	CRYPTO_CHACHA20:          "CHACHA20",
+	CRYPTO_POLY1305:          "POLY1305",
+	CRYPTO_CHACHA20_POLY1305: "CHACHA20_POLY1305",
+	CRYPTO_SHA3_224:          "SHA3_224",
+	CRYPTO_SHA3_256:          "SHA3_256",
+	CRYPTO_SHA3_384:          "SHA3_384",
+	CRYPTO_SHA3_512:          "SHA3_512",
+	CRYPTO_HMAC_SHA3_224:     "HMAC_SHA3_224",
+	CRYPTO_HMAC_SHA3_256:     "HMAC_SHA3_256",
+	CRYPTO_HMAC_SHA3_384:     "HMAC_SHA3_384",
+	CRYPTO_HMAC_SHA3_512:     "HMAC_SHA3_512",
+	CRYPTO_SHAKE_128:         "SHAKE_128",
+	CRYPTO_SHAKE_256:         "SHAKE_256",
+	CRYPTO_ARIA:              "ARIA",
+	CRYPTO_SEED:              "SEED",
+	CRYPTO_SM2:               "SM2",
+	CRYPTO_SM3:               "SM3",
+	CRYPTO_SM4:               "SM4",
+	CRYPTO_GOST_R_34_10_2012: "GOST_R_34_10_2012",
+	CRYPTO_GOST_R_34_11_2012: "GOST_R_34_11_2012",
+	CRYPTO_GOST_R_34_13_2015: "GOST_R_34_13_2015",
+	CRYPTO_GOST_28147_89:     "GOST_28147_89",
+	CRYPTO_XMSS:              "XMSS",
+	CRYPTO_SPHINCS_256:       "SPHINCS_256",
+	CRYPTO_MCELIECE:          "MCELIECE",
+	CRYPTO_MCELIECE_6960119:  "MCELIECE_6960119",
+	CRYPTO_MCELIECE_8192128:  "MCELIECE_8192128",
+	CRYPTO_ED25519:           "ED25519",
+	CRYPTO_ED448:             "ED448",
+}
+
+var enumNamesPaddingMethod = map[Enum]string{
+	PADDING_METHOD_NONE:        "NONE",
+	PADDING_METHOD_OAEP:        "OAEP",
+	PADDING_METHOD_PKCS_5:      "PKCS_5",
+	PADDING_METHOD_SSL_3:       "SSL_3",
+	PADDING_METHOD_ZEROS:       "ZEROS",
+	PADDING_METHOD_ANSI_X9_23:  "ANSI_X9_23",
+	PADDING_METHOD_ISO_10126:   "ISO_10126",
+	PADDING_METHOD_PKCS_1_V1_5: "PKCS_1_V1_5",
+	PADDING_METHOD_X9_31:       "X9_31",
+	PADDING_METHOD_PSS:         "PSS",
+}
+
+var enumNamesHash = map[Enum]string{
+	HASH_MD2:        "MD2",
+	HASH_MD4:        "MD4",
+	HASH_MD5:        "MD5",
+	HASH_SHA1:       "SHA1",
+	HASH_SHA224:     "SHA224",
+	HASH_SHA256:     "SHA256",
+	HASH_SHA384:     "SHA384",
+	HASH_SHA512:     "SHA512",
+	HASH_RIPEMD_160: "RIPEMD_160",
+	HASH_TIGER:      "TIGER",
+	HASH_WHIRLPOOL:  "WHIRLPOOL",
+	HASH_SHA512_224: "SHA512_224",
+	HASH_SHA512_256: "SHA512_256",
+	HASH_SHA3_224:   "SHA3_224",
+	HASH_SHA3_256:   "SHA3_256",
+	HASH_SHA3_384:   "SHA3_384",
+	HASH_SHA3_512:   "SHA3_512",
+}
+
+var enumNamesCredentialType = map[Enum]string{
+	CREDENTIAL_TYPE_USERNAME_AND_PASSWORD: "USERNAME_AND_PASSWORD",
+	CREDENTIAL_TYPE_DEVICE:                "DEVICE",
+	CREDENTIAL_TYPE_ATTESTATION:           "ATTESTATION",
+	CREDENTIAL_TYPE_ONE_TIME_PASSWORD:     "ONE_TIME_PASSWORD",
+	CREDENTIAL_TYPE_HASHED_PASSWORD:       "HASHED_PASSWORD",
+	CREDENTIAL_TYPE_TICKET:                "TICKET",
+}
+
+var enumNamesResultStatus = map[Enum]string{
+	RESULT_STATUS_SUCCESS:           "SUCCESS",
+	RESULT_STATUS_OPERATION_FAILED:  "OPERATION_FAILED",
+	RESULT_STATUS_OPERATION_PENDING: "OPERATION_PENDING",
+	RESULT_STATUS_OPERATION_UNDONE:  "OPERATION_UNDONE",
+}
+
+var enumNamesResultReason = map[Enum]string{
+	RESULT_REASON_ITEM_NOT_FOUND:                         "ITEM_NOT_FOUND",
+	RESULT_REASON_RESPONSE_TOO_LARGE:                     "RESPONSE_TOO_LARGE",
+	RESULT_REASON_AUTHENTICATION_NOT_SUCCESSFUL:          "AUTHENTICATION_NOT_SUCCESSFUL",
+	RESULT_REASON_INVALID_MESSAGE:                        "INVALID_MESSAGE",
+	RESULT_REASON_OPERATION_NOT_SUPPORTED:                "OPERATION_NOT_SUPPORTED",
+	RESULT_REASON_MISSING_DATA:                           "MISSING_DATA",
+	RESULT_REASON_INVALID_FIELD:                          "INVALID_FIELD",
+	RESULT_REASON_FEATURE_NOT_SUPPORTED:                  "FEATURE_NOT_SUPPORTED",
+	RESULT_REASON_OPERATION_CANCELED_BY_REQUESTER:        "OPERATION_CANCELED_BY_REQUESTER",
+	RESULT_REASON_CRYPTOGRAPHIC_FAILURE:                  "CRYPTOGRAPHIC_FAILURE",
+	RESULT_REASON_ILLEGAL_OPERATION:                      "ILLEGAL_OPERATION",
+	RESULT_REASON_PERMISSION_DENIED:                      "PERMISSION_DENIED",
+	RESULT_REASON_OBJECT_ARCHIVED:                        "OBJECT_ARCHIVED",
+	RESULT_REASON_INDEX_OUT_OF_BOUNDS:                    "INDEX_OUT_OF_BOUNDS",
+	RESULT_REASON_APPLICATION_NAMESPACE_NOT_SUPPORTED:    "APPLICATION_NAMESPACE_NOT_SUPPORTED",
+	RESULT_REASON_KEY_FORMAT_TYPE_NOT_SUPPORTED:          "KEY_FORMAT_TYPE_NOT_SUPPORTED",
+	RESULT_REASON_KEY_COMPRESSION_TYPE_NOT_SUPPORTED:     "KEY_COMPRESSION_TYPE_NOT_SUPPORTED",
+	RESULT_REASON_ENCODING_OPTION_ERROR:                  "ENCODING_OPTION_ERROR",
+	RESULT_REASON_KEY_VALUE_NOT_PRESENT:                  "KEY_VALUE_NOT_PRESENT",
+	RESULT_REASON_ATTESTATION_REQUIRED:                   "ATTESTATION_REQUIRED",
+	RESULT_REASON_ATTESTATION_FAILED:                     "ATTESTATION_FAILED",
+	RESULT_REASON_SENSITIVE:                              "SENSITIVE",
+	RESULT_REASON_NOT_EXTRACTABLE:                        "NOT_EXTRACTABLE",
+	RESULT_REASON_OBJECT_ALREADY_EXISTS:                  "OBJECT_ALREADY_EXISTS",
+	RESULT_REASON_INVALID_TICKET:                         "INVALID_TICKET",
+	RESULT_REASON_USAGE_LIMIT_EXCEEDED:                   "USAGE_LIMIT_EXCEEDED",
+	RESULT_REASON_NUMERIC_RANGE:                          "NUMERIC_RANGE",
+	RESULT_REASON_INVALID_DATA_TYPE:                      "INVALID_DATA_TYPE",
+	RESULT_REASON_READ_ONLY_ATTRIBUTE:                    "READ_ONLY_ATTRIBUTE",
+	RESULT_REASON_MULTI_VALUED_ATTRIBUTE:                 "MULTI_VALUED_ATTRIBUTE",
+	RESULT_REASON_UNSUPPORTED_ATTRIBUTE:                  "UNSUPPORTED_ATTRIBUTE",
+	RESULT_REASON_ATTRIBUTE_INSTANCE_NOT_FOUND:           "ATTRIBUTE_INSTANCE_NOT_FOUND",
+	RESULT_REASON_ATTRIBUTE_NOT_FOUND:                    "ATTRIBUTE_NOT_FOUND",
+	RESULT_REASON_ATTRIBUTE_READ_ONLY:                    "ATTRIBUTE_READ_ONLY",
+	RESULT_REASON_ATTRIBUTE_SINGLE_VALUED:                "ATTRIBUTE_SINGLE_VALUED",
+	RESULT_REASON_BAD_CRYPTOGRAPHIC_PARAMETERS:           "BAD_CRYPTOGRAPHIC_PARAMETERS",
+	RESULT_REASON_BAD_PASSWORD:                           "BAD_PASSWORD",
+	RESULT_REASON_CODEC_ERROR:                            "CODEC_ERROR",
+	RESULT_REASON_ILLEGAL_OBJECT_TYPE:                    "ILLEGAL_OBJECT_TYPE",
+	RESULT_REASON_INCOMPATIBLE_CRYPTOGRAPHIC_USAGE_MASK:  "INCOMPATIBLE_CRYPTOGRAPHIC_USAGE_MASK",
+	RESULT_REASON_INTERNAL_SERVER_ERROR:                  "INTERNAL_SERVER_ERROR",
+	RESULT_REASON_INVALID_ASYNCHRONOUS_CORRELATION_VALUE: "INVALID_ASYNCHRONOUS_CORRELATION_VALUE",
+	RESULT_REASON_INVALID_ATTRIBUTE:                      "INVALID_ATTRIBUTE",
+	RESULT_REASON_INVALID_ATTRIBUTE_VALUE:                "INVALID_ATTRIBUTE_VALUE",
+	RESULT_REASON_INVALID_CORRELATION_VALUE:              "INVALID_CORRELATION_VALUE",
+	RESULT_REASON_INVALID_CSR:                            "INVALID_CSR",
+	RESULT_REASON_INVALID_OBJECT_TYPE:                    "INVALID_OBJECT_TYPE",
+	RESULT_REASON_KEY_WRAP_TYPE_NOT_SUPPORTED:            "KEY_WRAP_TYPE_NOT_SUPPORTED",
+	RESULT_REASON_MISSING_INITIALIZATION_VECTOR:          "MISSING_INITIALIZATION_VECTOR",
+	RESULT_REASON_NON_UNIQUE_NAME_ATTRIBUTE:              "NON_UNIQUE_NAME_ATTRIBUTE",
+	RESULT_REASON_OBJECT_DESTROYED:                       "OBJECT_DESTROYED",
+	RESULT_REASON_OBJECT_NOT_FOUND:                       "OBJECT_NOT_FOUND",
+	RESULT_REASON_NOT_AUTHORISED:                         "NOT_AUTHORISED",
+	RESULT_REASON_SERVER_LIMIT_EXCEEDED:                  "SERVER_LIMIT_EXCEEDED",
+	RESULT_REASON_UNKNOWN_ENUMERATION:                    "UNKNOWN_ENUMERATION",
+	RESULT_REASON_UNKNOWN_MESSAGE_EXTENSION:              "UNKNOWN_MESSAGE_EXTENSION",
+	RESULT_REASON_UNKNOWN_TAG:                            "UNKNOWN_TAG",
+	RESULT_REASON_UNSUPPORTED_CRYPTOGRAPHIC_PARAMETERS:   "UNSUPPORTED_CRYPTOGRAPHIC_PARAMETERS",
+	RESULT_REASON_UNSUPPORTED_PROTOCOL_VERSION:           "UNSUPPORTED_PROTOCOL_VERSION",
+	RESULT_REASON_WRAPPING_OBJECT_ARCHIVED:               "WRAPPING_OBJECT_ARCHIVED",
+	RESULT_REASON_WRAPPING_OBJECT_DESTROYED:              "WRAPPING_OBJECT_DESTROYED",
+	RESULT_REASON_WRAPPING_OBJECT_NOT_FOUND:              "WRAPPING_OBJECT_NOT_FOUND",
+	RESULT_REASON_WRONG_KEY_LIFECYCLE_STATE:              "WRONG_KEY_LIFECYCLE_STATE",
+	RESULT_REASON_PROTECTION_STORAGE_UNAVAILABLE:         "PROTECTION_STORAGE_UNAVAILABLE",
+	RESULT_REASON_PKCS11_CODEC_ERROR:                     "PKCS11_CODEC_ERROR",
+	RESULT_REASON_PKCS11_INVALID_FUNCTION:                "PKCS11_INVALID_FUNCTION",
+	RESULT_REASON_PKCS11_INVALID_INTERFACE:               "PKCS11_INVALID_INTERFACE",
+	RESULT_REASON_GENERAL_FAILURE:                        "GENERAL_FAILURE",
+}
+
+var enumNamesRevocationReason = map[Enum]string{
+	REVOCATION_REASON_UNSPECIFIED:            "UNSPECIFIED",
+	REVOCATION_REASON_KEY_COMPROMISE:         "KEY_COMPROMISE",
+	REVOCATION_REASON_CA_COMPROMISE:          "CA_COMPROMISE",
+	REVOCATION_REASON_AFFILIATION_CHANGED:    "AFFILIATION_CHANGED",
+	REVOCATION_REASON_SUPERSEDED:             "SUPERSEDED",
+	REVOCATION_REASON_CESSATION_OF_OPERATION: "CESSATION_OF_OPERATION",
+	REVOCATION_REASON_PRIVILEGE_WITHDRAWN:    "PRIVILEGE_WITHDRAWN",
+}
+
+var enumNamesBlockMode = map[Enum]string{
+	BLOCK_MODE_CBC:               "CBC",
+	BLOCK_MODE_ECB:               "ECB",
+	BLOCK_MODE_PCBC:              "PCBC",
+	BLOCK_MODE_CFB:               "CFB",
+	BLOCK_MODE_OFB:               "OFB",
+	BLOCK_MODE_CTR:               "CTR",
+	BLOCK_MODE_CMAC:              "CMAC",
+	BLOCK_MODE_CCM:               "CCM",
+	BLOCK_MODE_GCM:               "GCM",
+	BLOCK_MODE_CBC_MAC:           "CBC_MAC",
+	BLOCK_MODE_XTS:               "XTS",
+	BLOCK_MODE_AESKeyWrapPadding: "AESKeyWrapPadding",
+	BLOCK_MODE_NISTKeyWrap:       "NISTKeyWrap",
+	BLOCK_MODE_X9_102_AESKW:      "X9_102_AESKW",
+	BLOCK_MODE_X9_102_TDKW:       "X9_102_TDKW",
+	BLOCK_MODE_X9_102_AKW1:       "X9_102_AKW1",
+	BLOCK_MODE_X9_102_AKW2:       "X9_102_AKW2",
+	BLOCK_MODE_AEAD:              "AEAD",
+}
+
+var enumNamesLinkType = map[Enum]string{
+	LINK_TYPE_CERTIFICATE:            "CERTIFICATE",
+	LINK_TYPE_PUBLIC_KEY:             "PUBLIC_KEY",
+	LINK_TYPE_PRIVATE_KEY:            "PRIVATE_KEY",
+	LINK_TYPE_DERIVATION_BASE_OBJECT: "DERIVATION_BASE_OBJECT",
+	LINK_TYPE_DERIVED_KEY:            "DERIVED_KEY",
+	LINK_TYPE_REPLACEMENT_OBJECT:     "REPLACEMENT_OBJECT",
+	LINK_TYPE_REPLACED_OBJECT:        "REPLACED_OBJECT",
+	LINK_TYPE_PARENT:                 "PARENT",
+	LINK_TYPE_CHILD:                  "CHILD",
+	LINK_TYPE_PREVIOUS:               "PREVIOUS",
+	LINK_TYPE_NEXT:                   "NEXT",
+	LINK_TYPE_PKCS12_CERTIFICATE:     "PKCS12_CERTIFICATE",
+	LINK_TYPE_PKCS12_PASSWORD:        "PKCS12_PASSWORD",
+	LINK_TYPE_WRAPPING_KEY:           "WRAPPING_KEY",
+}
+
+var enumNamesUsageLimitsUnit = map[Enum]string{
+	USAGE_LIMITS_UNIT_BYTE:   "BYTE",
+	USAGE_LIMITS_UNIT_OBJECT: "OBJECT",
+}
+
+var enumNamesCertificateType = map[Enum]string{
+	CERTIFICATE_TYPE_X_509: "X_509",
+	CERTIFICATE_TYPE_PGP:   "PGP",
+}
+
+var enumNamesSecretDataType = map[Enum]string{
+	SECRET_DATA_TYPE_PASSWORD: "PASSWORD",
+	SECRET_DATA_TYPE_SEED:     "SEED",
+}
+
+var enumNamesSplitKeyMethod = map[Enum]string{
+	SPLIT_KEY_METHOD_XOR:                        "XOR",
+	SPLIT_KEY_METHOD_POLYNOMIAL_SHARING_GF_2_16: "POLYNOMIAL_SHARING_GF_2_16",
+	SPLIT_KEY_METHOD_POLYNOMIAL_SHARING_PRIME:   "POLYNOMIAL_SHARING_PRIME",
+	SPLIT_KEY_METHOD_POLYNOMIAL_SHARING_GF_2_8:  "POLYNOMIAL_SHARING_GF_2_8",
+}
+
+var enumNamesCertificateRequestType = map[Enum]string{
+	CERTIFICATE_REQUEST_TYPE_CRMF:    "CRMF",
+	CERTIFICATE_REQUEST_TYPE_PKCS_10: "PKCS_10",
+	CERTIFICATE_REQUEST_TYPE_PEM:     "PEM",
+	CERTIFICATE_REQUEST_TYPE_PGP:     "PGP",
+}
+
+var enumNamesRecommendedCurve = map[Enum]string{
+	RECOMMENDED_CURVE_P_192: "P_192",
+	RECOMMENDED_CURVE_K_163: "K_163",
+	RECOMMENDED_CURVE_B_163: "B_163",
+	RECOMMENDED_CURVE_P_224: "P_224",
+	RECOMMENDED_CURVE_K_233: "K_233",
+	RECOMMENDED_CURVE_B_233: "B_233",
+	RECOMMENDED_CURVE_P_256: "P_256",
+	RECOMMENDED_CURVE_K_283: "K_283",
+	RECOMMENDED_CURVE_B_283: "B_283",
+	RECOMMENDED_CURVE_P_384: "P_384",
+	RECOMMENDED_CURVE_K_409: "K_409",
+	RECOMMENDED_CURVE_B_409: "B_409",
+	RECOMMENDED_CURVE_P_521: "P_521",
+	RECOMMENDED_CURVE_K_571: "K_571",
+	RECOMMENDED_CURVE_B_571: "B_571",
+}
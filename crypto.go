@@ -0,0 +1,471 @@
+package kmip
+
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// recommendedCurves maps KMIP Recommended Curve values to Go curves
+var recommendedCurves = []struct {
+	curve Enum
+	c     elliptic.Curve
+}{
+	{RECOMMENDED_CURVE_P_224, elliptic.P224()},
+	{RECOMMENDED_CURVE_P_256, elliptic.P256()},
+	{RECOMMENDED_CURVE_P_384, elliptic.P384()},
+	{RECOMMENDED_CURVE_P_521, elliptic.P521()},
+}
+
+func curveByEnum(curve Enum) (elliptic.Curve, error) {
+	for _, rc := range recommendedCurves {
+		if rc.curve == curve {
+			return rc.c, nil
+		}
+	}
+
+	return nil, errors.Errorf("unsupported recommended curve %s", EnumName(RECOMMENDED_CURVE, curve))
+}
+
+func curveToEnum(c elliptic.Curve) (Enum, error) {
+	for _, rc := range recommendedCurves {
+		if rc.c.Params().Name == c.Params().Name {
+			return rc.curve, nil
+		}
+	}
+
+	return 0, errors.Errorf("unsupported curve %s", c.Params().Name)
+}
+
+// rsaPublicExponent verifies transparent RSA modulus and public exponent and converts exponent to int
+func rsaPublicExponent(modulus, exponent *big.Int) (int, error) {
+	if modulus == nil || exponent == nil {
+		return 0, errors.New("transparent RSA key without modulus or public exponent")
+	}
+
+	if !exponent.IsInt64() || exponent.Int64() > int64(^uint32(0)>>1) {
+		return 0, errors.New("public exponent is too large")
+	}
+
+	return int(exponent.Int64()), nil
+}
+
+// keyBytes returns Key Material of the key block as bytes
+func (kb KeyBlock) keyBytes() ([]byte, error) {
+	if kb.WrappingData.WrappingMethod != 0 {
+		return nil, errors.New("wrapped keys are not supported")
+	}
+
+	b, ok := kb.Value.KeyMaterial.([]byte)
+	if !ok {
+		return nil, errors.Errorf("unexpected key material %T for format %s", kb.Value.KeyMaterial, EnumName(KEY_FORMAT_TYPE, kb.FormatType))
+	}
+
+	return b, nil
+}
+
+// NewSymmetricKey builds symmetric key object with Raw key format
+//
+// algorithm is the cryptographic algorithm of the key (e.g. CRYPTO_AES).
+func NewSymmetricKey(algorithm Enum, key []byte) SymmetricKey {
+	return SymmetricKey{
+		KeyBlock: KeyBlock{
+			FormatType:             KEY_FORMAT_RAW,
+			Value:                  KeyValue{KeyMaterial: key},
+			CryptographicAlgorithm: algorithm,
+			CryptographicLength:    int32(len(key) * 8),
+		},
+	}
+}
+
+// Key returns key bytes of the symmetric key
+//
+// Raw, Opaque and Transparent Symmetric Key formats are supported.
+func (k SymmetricKey) Key() ([]byte, error) {
+	switch k.KeyBlock.FormatType {
+	case KEY_FORMAT_RAW, KEY_FORMAT_OPAQUE:
+		return k.KeyBlock.keyBytes()
+	case KEY_FORMAT_TRANSPARENT_SYMMETRIC_KEY:
+		m, ok := k.KeyBlock.Value.KeyMaterial.(TransparentSymmetricKey)
+		if !ok {
+			return nil, errors.Errorf("unexpected key material %T", k.KeyBlock.Value.KeyMaterial)
+		}
+
+		return m.Key, nil
+	default:
+		return nil, errors.Errorf("unsupported key format %s", EnumName(KEY_FORMAT_TYPE, k.KeyBlock.FormatType))
+	}
+}
+
+// NewPublicKey builds public key object from Go public key in the specified key format
+//
+// Supported keys are *rsa.PublicKey (PKCS#1, X.509 and Transparent RSA Public Key formats),
+// *ecdsa.PublicKey (X.509 and Transparent EC Public Key formats) and ed25519.PublicKey
+// (Raw and X.509 formats).
+func NewPublicKey(pub crypto.PublicKey, formatType Enum) (k PublicKey, err error) {
+	kb := &k.KeyBlock
+	kb.FormatType = formatType
+
+	switch key := pub.(type) {
+	case *rsa.PublicKey:
+		kb.CryptographicAlgorithm = CRYPTO_RSA
+		kb.CryptographicLength = int32(key.N.BitLen())
+
+		switch formatType {
+		case KEY_FORMAT_PKCS_1:
+			kb.Value.KeyMaterial = x509.MarshalPKCS1PublicKey(key)
+		case KEY_FORMAT_TRANSPARENT_RSA_PUBLIC_KEY:
+			kb.Value.KeyMaterial = TransparentRSAPublicKey{
+				Modulus:        key.N,
+				PublicExponent: big.NewInt(int64(key.E)),
+			}
+		}
+	case *ecdsa.PublicKey:
+		kb.CryptographicAlgorithm = CRYPTO_ECDSA
+		kb.CryptographicLength = int32(key.Curve.Params().BitSize)
+
+		if formatType == KEY_FORMAT_TRANSPARENT_EC_PUBLIC_KEY {
+			var curve Enum
+
+			if curve, err = curveToEnum(key.Curve); err != nil {
+				return
+			}
+
+			kb.Value.KeyMaterial = TransparentECPublicKey{
+				RecommendedCurve: curve,
+				QString:          elliptic.Marshal(key.Curve, key.X, key.Y),
+			}
+		}
+	case ed25519.PublicKey:
+		kb.CryptographicAlgorithm = CRYPTO_ED25519
+		kb.CryptographicLength = 256
+
+		if formatType == KEY_FORMAT_RAW {
+			kb.Value.KeyMaterial = []byte(key)
+		}
+	default:
+		err = errors.Errorf("unsupported public key type %T", pub)
+		return
+	}
+
+	if formatType == KEY_FORMAT_X_509 {
+		kb.Value.KeyMaterial, err = x509.MarshalPKIXPublicKey(pub)
+		if err != nil {
+			return
+		}
+	}
+
+	if kb.Value.KeyMaterial == nil {
+		err = errors.Errorf("unsupported key format %s for public key type %T", EnumName(KEY_FORMAT_TYPE, formatType), pub)
+	}
+
+	return
+}
+
+// CryptoPublicKey converts public key object into Go public key
+//
+// Result is one of *rsa.PublicKey, *ecdsa.PublicKey or ed25519.PublicKey, see NewPublicKey
+// for the list of supported key formats.
+func (k PublicKey) CryptoPublicKey() (pub crypto.PublicKey, err error) {
+	kb := k.KeyBlock
+
+	switch kb.FormatType {
+	case KEY_FORMAT_RAW:
+		var b []byte
+
+		if b, err = kb.keyBytes(); err != nil {
+			return
+		}
+
+		if kb.CryptographicAlgorithm != CRYPTO_ED25519 || len(b) != ed25519.PublicKeySize {
+			err = errors.Errorf("unsupported raw public key for algorithm %s", EnumName(CRYPTOGRAPHIC_ALGORITHM, kb.CryptographicAlgorithm))
+			return
+		}
+
+		pub = ed25519.PublicKey(b)
+	case KEY_FORMAT_PKCS_1:
+		var b []byte
+
+		if b, err = kb.keyBytes(); err != nil {
+			return
+		}
+
+		pub, err = x509.ParsePKCS1PublicKey(b)
+	case KEY_FORMAT_X_509:
+		var b []byte
+
+		if b, err = kb.keyBytes(); err != nil {
+			return
+		}
+
+		pub, err = x509.ParsePKIXPublicKey(b)
+	case KEY_FORMAT_TRANSPARENT_RSA_PUBLIC_KEY:
+		m, ok := kb.Value.KeyMaterial.(TransparentRSAPublicKey)
+		if !ok {
+			err = errors.Errorf("unexpected key material %T", kb.Value.KeyMaterial)
+			return
+		}
+
+		var e int
+
+		if e, err = rsaPublicExponent(m.Modulus, m.PublicExponent); err != nil {
+			return
+		}
+
+		pub = &rsa.PublicKey{
+			N: m.Modulus,
+			E: e,
+		}
+	case KEY_FORMAT_TRANSPARENT_EC_PUBLIC_KEY, KEY_FORMAT_TRANSPARENT_ECDSA_PUBLIC_KEY,
+		KEY_FORMAT_TRANSPARENT_ECDH_PUBLIC_KEY, KEY_FORMAT_TRANSPARENT_ECMQV_PUBLIC_KEY:
+		m, ok := kb.Value.KeyMaterial.(TransparentECPublicKey)
+		if !ok {
+			err = errors.Errorf("unexpected key material %T", kb.Value.KeyMaterial)
+			return
+		}
+
+		var curve elliptic.Curve
+
+		if curve, err = curveByEnum(m.RecommendedCurve); err != nil {
+			return
+		}
+
+		x, y := elliptic.Unmarshal(curve, m.QString)
+		if x == nil {
+			err = errors.New("invalid EC public key")
+			return
+		}
+
+		pub = &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+	default:
+		err = errors.Errorf("unsupported key format %s", EnumName(KEY_FORMAT_TYPE, kb.FormatType))
+	}
+
+	return
+}
+
+// NewPrivateKey builds private key object from Go private key in the specified key format
+//
+// Supported keys are *rsa.PrivateKey (PKCS#1, PKCS#8 and Transparent RSA Private Key formats),
+// *ecdsa.PrivateKey (PKCS#8, EC Private Key and Transparent EC Private Key formats) and
+// ed25519.PrivateKey (Raw and PKCS#8 formats, Raw format holds the private key seed).
+func NewPrivateKey(priv crypto.PrivateKey, formatType Enum) (k PrivateKey, err error) {
+	kb := &k.KeyBlock
+	kb.FormatType = formatType
+
+	switch key := priv.(type) {
+	case *rsa.PrivateKey:
+		kb.CryptographicAlgorithm = CRYPTO_RSA
+		kb.CryptographicLength = int32(key.N.BitLen())
+
+		switch formatType {
+		case KEY_FORMAT_PKCS_1:
+			kb.Value.KeyMaterial = x509.MarshalPKCS1PrivateKey(key)
+		case KEY_FORMAT_TRANSPARENT_RSA_PRIVATE_KEY:
+			m := TransparentRSAPrivateKey{
+				Modulus:         key.N,
+				PrivateExponent: key.D,
+				PublicExponent:  big.NewInt(int64(key.E)),
+			}
+
+			if len(key.Primes) == 2 {
+				// CRT values are computed here, as key.Precompute() would modify the caller's key
+				p, q := key.Primes[0], key.Primes[1]
+				one := big.NewInt(1)
+
+				m.P = p
+				m.Q = q
+				m.PrimeExponentP = new(big.Int).Mod(key.D, new(big.Int).Sub(p, one))
+				m.PrimeExponentQ = new(big.Int).Mod(key.D, new(big.Int).Sub(q, one))
+				m.CRTCoefficient = new(big.Int).ModInverse(q, p)
+			}
+
+			kb.Value.KeyMaterial = m
+		}
+	case *ecdsa.PrivateKey:
+		kb.CryptographicAlgorithm = CRYPTO_ECDSA
+		kb.CryptographicLength = int32(key.Curve.Params().BitSize)
+
+		switch formatType {
+		case KEY_FORMAT_EC_PRIVATE_KEY:
+			kb.Value.KeyMaterial, err = x509.MarshalECPrivateKey(key)
+			if err != nil {
+				return
+			}
+		case KEY_FORMAT_TRANSPARENT_EC_PRIVATE_KEY:
+			var curve Enum
+
+			if curve, err = curveToEnum(key.Curve); err != nil {
+				return
+			}
+
+			kb.Value.KeyMaterial = TransparentECPrivateKey{
+				RecommendedCurve: curve,
+				D:                key.D,
+			}
+		}
+	case ed25519.PrivateKey:
+		kb.CryptographicAlgorithm = CRYPTO_ED25519
+		kb.CryptographicLength = 256
+
+		if formatType == KEY_FORMAT_RAW {
+			kb.Value.KeyMaterial = key.Seed()
+		}
+	default:
+		err = errors.Errorf("unsupported private key type %T", priv)
+		return
+	}
+
+	if formatType == KEY_FORMAT_PKCS_8 {
+		kb.Value.KeyMaterial, err = x509.MarshalPKCS8PrivateKey(priv)
+		if err != nil {
+			return
+		}
+	}
+
+	if kb.Value.KeyMaterial == nil {
+		err = errors.Errorf("unsupported key format %s for private key type %T", EnumName(KEY_FORMAT_TYPE, formatType), priv)
+	}
+
+	return
+}
+
+// CryptoPrivateKey converts private key object into Go private key
+//
+// Result is one of *rsa.PrivateKey, *ecdsa.PrivateKey or ed25519.PrivateKey, see NewPrivateKey
+// for the list of supported key formats. Transparent RSA private keys should contain
+// both primes P and Q.
+func (k PrivateKey) CryptoPrivateKey() (priv crypto.PrivateKey, err error) {
+	kb := k.KeyBlock
+
+	switch kb.FormatType {
+	case KEY_FORMAT_RAW:
+		var b []byte
+
+		if b, err = kb.keyBytes(); err != nil {
+			return
+		}
+
+		if kb.CryptographicAlgorithm != CRYPTO_ED25519 || len(b) != ed25519.SeedSize {
+			err = errors.Errorf("unsupported raw private key for algorithm %s", EnumName(CRYPTOGRAPHIC_ALGORITHM, kb.CryptographicAlgorithm))
+			return
+		}
+
+		priv = ed25519.NewKeyFromSeed(b)
+	case KEY_FORMAT_PKCS_1:
+		var b []byte
+
+		if b, err = kb.keyBytes(); err != nil {
+			return
+		}
+
+		priv, err = x509.ParsePKCS1PrivateKey(b)
+	case KEY_FORMAT_PKCS_8:
+		var b []byte
+
+		if b, err = kb.keyBytes(); err != nil {
+			return
+		}
+
+		priv, err = x509.ParsePKCS8PrivateKey(b)
+	case KEY_FORMAT_EC_PRIVATE_KEY:
+		var b []byte
+
+		if b, err = kb.keyBytes(); err != nil {
+			return
+		}
+
+		priv, err = x509.ParseECPrivateKey(b)
+	case KEY_FORMAT_TRANSPARENT_RSA_PRIVATE_KEY:
+		m, ok := kb.Value.KeyMaterial.(TransparentRSAPrivateKey)
+		if !ok {
+			err = errors.Errorf("unexpected key material %T", kb.Value.KeyMaterial)
+			return
+		}
+
+		if m.PrivateExponent == nil || m.PublicExponent == nil || m.P == nil || m.Q == nil {
+			err = errors.New("transparent RSA private key without private exponent, public exponent and primes is not supported")
+			return
+		}
+
+		var e int
+
+		if e, err = rsaPublicExponent(m.Modulus, m.PublicExponent); err != nil {
+			return
+		}
+
+		key := &rsa.PrivateKey{
+			PublicKey: rsa.PublicKey{
+				N: m.Modulus,
+				E: e,
+			},
+			D:      m.PrivateExponent,
+			Primes: []*big.Int{m.P, m.Q},
+		}
+
+		if err = key.Validate(); err != nil {
+			return
+		}
+
+		key.Precompute()
+
+		priv = key
+	case KEY_FORMAT_TRANSPARENT_EC_PRIVATE_KEY, KEY_FORMAT_TRANSPARENT_ECDSA_PRIVATE_KEY,
+		KEY_FORMAT_TRANSPARENT_ECDH_PRIVATE_KEY, KEY_FORMAT_TRANSPARENT_ECMQV_PRIVATE_KEY:
+		m, ok := kb.Value.KeyMaterial.(TransparentECPrivateKey)
+		if !ok {
+			err = errors.Errorf("unexpected key material %T", kb.Value.KeyMaterial)
+			return
+		}
+
+		var curve elliptic.Curve
+
+		if curve, err = curveByEnum(m.RecommendedCurve); err != nil {
+			return
+		}
+
+		if m.D == nil || m.D.Sign() <= 0 || m.D.Cmp(curve.Params().N) >= 0 {
+			err = errors.New("invalid EC private key")
+			return
+		}
+
+		key := &ecdsa.PrivateKey{
+			PublicKey: ecdsa.PublicKey{Curve: curve},
+			D:         m.D,
+		}
+		key.PublicKey.X, key.PublicKey.Y = curve.ScalarBaseMult(m.D.Bytes())
+
+		priv = key
+	default:
+		err = errors.Errorf("unsupported key format %s", EnumName(KEY_FORMAT_TYPE, kb.FormatType))
+	}
+
+	return
+}
+
+// NewCertificate builds certificate object from X.509 certificate
+func NewCertificate(cert *x509.Certificate) Certificate {
+	return Certificate{
+		CertificateType:  CERTIFICATE_TYPE_X_509,
+		CertificateValue: cert.Raw,
+	}
+}
+
+// X509Certificate parses X.509 certificate object
+func (c Certificate) X509Certificate() (*x509.Certificate, error) {
+	if c.CertificateType != CERTIFICATE_TYPE_X_509 {
+		return nil, errors.Errorf("unsupported certificate type %s", EnumName(CERTIFICATE_TYPE, c.CertificateType))
+	}
+
+	return x509.ParseCertificate(c.CertificateValue)
+}
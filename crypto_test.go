@@ -0,0 +1,251 @@
+package kmip
+
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CryptoSuite struct {
+	suite.Suite
+
+	rsaKey     *rsa.PrivateKey
+	ecdsaKey   *ecdsa.PrivateKey
+	ed25519Key ed25519.PrivateKey
+}
+
+func (s *CryptoSuite) SetupSuite() {
+	var err error
+
+	s.rsaKey, err = rsa.GenerateKey(rand.Reader, 1024)
+	s.Require().NoError(err)
+
+	s.ecdsaKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+
+	_, s.ed25519Key, err = ed25519.GenerateKey(rand.Reader)
+	s.Require().NoError(err)
+}
+
+func (s *CryptoSuite) roundTrip(resp GetResponse) GetResponse {
+	data, err := Marshal(&resp)
+	s.Require().NoError(err)
+
+	var decoded GetResponse
+	s.Require().NoError(NewDecoder(bytes.NewReader(data)).Decode(&decoded))
+
+	return decoded
+}
+
+func (s *CryptoSuite) TestSymmetricKey() {
+	key := []byte("0123456789abcdef")
+
+	k := NewSymmetricKey(CRYPTO_AES, key)
+	s.Assert().Equal(CRYPTO_AES, k.KeyBlock.CryptographicAlgorithm)
+	s.Assert().Equal(int32(128), k.KeyBlock.CryptographicLength)
+
+	resp := s.roundTrip(GetResponse{ObjectType: OBJECT_TYPE_SYMMETRIC_KEY, UniqueIdentifier: "1", SymmetricKey: k})
+
+	b, err := resp.SymmetricKey.Key()
+	s.Require().NoError(err)
+	s.Assert().Equal(key, b)
+
+	k = SymmetricKey{
+		KeyBlock: KeyBlock{
+			FormatType: KEY_FORMAT_TRANSPARENT_SYMMETRIC_KEY,
+			Value:      KeyValue{KeyMaterial: TransparentSymmetricKey{Key: key}},
+		},
+	}
+
+	b, err = k.Key()
+	s.Require().NoError(err)
+	s.Assert().Equal(key, b)
+
+	k.KeyBlock.FormatType = KEY_FORMAT_PKCS_1
+	_, err = k.Key()
+	s.Assert().EqualError(err, "unsupported key format PKCS_1")
+}
+
+func (s *CryptoSuite) TestPublicKey() {
+	for _, tc := range []struct {
+		key       crypto.PublicKey
+		format    Enum
+		algorithm Enum
+		length    int32
+	}{
+		{&s.rsaKey.PublicKey, KEY_FORMAT_PKCS_1, CRYPTO_RSA, 1024},
+		{&s.rsaKey.PublicKey, KEY_FORMAT_X_509, CRYPTO_RSA, 1024},
+		{&s.rsaKey.PublicKey, KEY_FORMAT_TRANSPARENT_RSA_PUBLIC_KEY, CRYPTO_RSA, 1024},
+		{&s.ecdsaKey.PublicKey, KEY_FORMAT_X_509, CRYPTO_ECDSA, 256},
+		{&s.ecdsaKey.PublicKey, KEY_FORMAT_TRANSPARENT_EC_PUBLIC_KEY, CRYPTO_ECDSA, 256},
+		{s.ed25519Key.Public(), KEY_FORMAT_RAW, CRYPTO_ED25519, 256},
+		{s.ed25519Key.Public(), KEY_FORMAT_X_509, CRYPTO_ED25519, 256},
+	} {
+		tc := tc
+
+		s.Run(EnumName(CRYPTOGRAPHIC_ALGORITHM, tc.algorithm)+"/"+EnumName(KEY_FORMAT_TYPE, tc.format), func() {
+			k, err := NewPublicKey(tc.key, tc.format)
+			s.Require().NoError(err)
+			s.Assert().Equal(tc.format, k.KeyBlock.FormatType)
+			s.Assert().Equal(tc.algorithm, k.KeyBlock.CryptographicAlgorithm)
+			s.Assert().Equal(tc.length, k.KeyBlock.CryptographicLength)
+
+			resp := s.roundTrip(GetResponse{ObjectType: OBJECT_TYPE_PUBLIC_KEY, UniqueIdentifier: "1", PublicKey: k})
+
+			pub, err := resp.PublicKey.CryptoPublicKey()
+			s.Require().NoError(err)
+			s.Assert().True(pub.(interface{ Equal(crypto.PublicKey) bool }).Equal(tc.key))
+		})
+	}
+}
+
+func (s *CryptoSuite) TestPrivateKey() {
+	for _, tc := range []struct {
+		key       crypto.Signer
+		format    Enum
+		algorithm Enum
+		length    int32
+	}{
+		{s.rsaKey, KEY_FORMAT_PKCS_1, CRYPTO_RSA, 1024},
+		{s.rsaKey, KEY_FORMAT_PKCS_8, CRYPTO_RSA, 1024},
+		{s.rsaKey, KEY_FORMAT_TRANSPARENT_RSA_PRIVATE_KEY, CRYPTO_RSA, 1024},
+		{s.ecdsaKey, KEY_FORMAT_PKCS_8, CRYPTO_ECDSA, 256},
+		{s.ecdsaKey, KEY_FORMAT_EC_PRIVATE_KEY, CRYPTO_ECDSA, 256},
+		{s.ecdsaKey, KEY_FORMAT_TRANSPARENT_EC_PRIVATE_KEY, CRYPTO_ECDSA, 256},
+		{s.ed25519Key, KEY_FORMAT_RAW, CRYPTO_ED25519, 256},
+		{s.ed25519Key, KEY_FORMAT_PKCS_8, CRYPTO_ED25519, 256},
+	} {
+		tc := tc
+
+		s.Run(EnumName(CRYPTOGRAPHIC_ALGORITHM, tc.algorithm)+"/"+EnumName(KEY_FORMAT_TYPE, tc.format), func() {
+			k, err := NewPrivateKey(tc.key, tc.format)
+			s.Require().NoError(err)
+			s.Assert().Equal(tc.format, k.KeyBlock.FormatType)
+			s.Assert().Equal(tc.algorithm, k.KeyBlock.CryptographicAlgorithm)
+			s.Assert().Equal(tc.length, k.KeyBlock.CryptographicLength)
+
+			resp := s.roundTrip(GetResponse{ObjectType: OBJECT_TYPE_PRIVATE_KEY, UniqueIdentifier: "1", PrivateKey: k})
+
+			priv, err := resp.PrivateKey.CryptoPrivateKey()
+			s.Require().NoError(err)
+			s.Assert().True(priv.(interface{ Equal(crypto.PrivateKey) bool }).Equal(tc.key))
+		})
+	}
+}
+
+func (s *CryptoSuite) TestPrivateKeyNotModified() {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	s.Require().NoError(err)
+
+	key.Precomputed = rsa.PrecomputedValues{}
+	orig := *key
+
+	k, err := NewPrivateKey(key, KEY_FORMAT_TRANSPARENT_RSA_PRIVATE_KEY)
+	s.Require().NoError(err)
+	s.Assert().Equal(orig, *key)
+
+	key.Precompute()
+
+	m := k.KeyBlock.Value.KeyMaterial.(TransparentRSAPrivateKey)
+	s.Assert().Equal(key.Precomputed.Dp, m.PrimeExponentP)
+	s.Assert().Equal(key.Precomputed.Dq, m.PrimeExponentQ)
+	s.Assert().Equal(key.Precomputed.Qinv, m.CRTCoefficient)
+}
+
+func (s *CryptoSuite) TestUnsupportedFormat() {
+	_, err := NewPublicKey(&s.ecdsaKey.PublicKey, KEY_FORMAT_PKCS_1)
+	s.Assert().EqualError(err, "unsupported key format PKCS_1 for public key type *ecdsa.PublicKey")
+
+	_, err = NewPrivateKey(s.ed25519Key, KEY_FORMAT_EC_PRIVATE_KEY)
+	s.Assert().EqualError(err, "unsupported key format EC_PRIVATE_KEY for private key type ed25519.PrivateKey")
+
+	_, err = NewPrivateKey("foo", KEY_FORMAT_RAW)
+	s.Assert().EqualError(err, "unsupported private key type string")
+
+	_, err = PrivateKey{KeyBlock: KeyBlock{FormatType: KEY_FORMAT_X_509}}.CryptoPrivateKey()
+	s.Assert().EqualError(err, "unsupported key format X_509")
+
+	_, err = PrivateKey{
+		KeyBlock: KeyBlock{
+			FormatType: KEY_FORMAT_TRANSPARENT_RSA_PRIVATE_KEY,
+			Value:      KeyValue{KeyMaterial: TransparentRSAPrivateKey{Modulus: s.rsaKey.N, PrivateExponent: s.rsaKey.D}},
+		},
+	}.CryptoPrivateKey()
+	s.Assert().EqualError(err, "transparent RSA private key without private exponent, public exponent and primes is not supported")
+
+	_, err = PublicKey{
+		KeyBlock: KeyBlock{
+			FormatType: KEY_FORMAT_TRANSPARENT_RSA_PUBLIC_KEY,
+			Value:      KeyValue{KeyMaterial: TransparentRSAPublicKey{Modulus: s.rsaKey.N}},
+		},
+	}.CryptoPublicKey()
+	s.Assert().EqualError(err, "transparent RSA key without modulus or public exponent")
+
+	_, err = PrivateKey{
+		KeyBlock: KeyBlock{
+			FormatType: KEY_FORMAT_TRANSPARENT_RSA_PRIVATE_KEY,
+			Value: KeyValue{KeyMaterial: TransparentRSAPrivateKey{
+				PrivateExponent: s.rsaKey.D,
+				PublicExponent:  big.NewInt(int64(s.rsaKey.E)),
+				P:               s.rsaKey.Primes[0],
+				Q:               s.rsaKey.Primes[1],
+			}},
+		},
+	}.CryptoPrivateKey()
+	s.Assert().EqualError(err, "transparent RSA key without modulus or public exponent")
+
+	_, err = PublicKey{
+		KeyBlock: KeyBlock{
+			FormatType: KEY_FORMAT_PKCS_1,
+			Value:      KeyValue{KeyMaterial: []byte{0x01}},
+			WrappingData: KeyWrappingData{
+				WrappingMethod: WRAPPING_METHOD_ENCRYPT,
+			},
+		},
+	}.CryptoPublicKey()
+	s.Assert().EqualError(err, "wrapped keys are not supported")
+}
+
+func (s *CryptoSuite) TestCertificate() {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &s.ecdsaKey.PublicKey, s.ecdsaKey)
+	s.Require().NoError(err)
+
+	cert, err := x509.ParseCertificate(der)
+	s.Require().NoError(err)
+
+	resp := s.roundTrip(GetResponse{ObjectType: OBJECT_TYPE_CERTIFICATE, UniqueIdentifier: "1", Certificate: NewCertificate(cert)})
+	s.Assert().Equal(CERTIFICATE_TYPE_X_509, resp.Certificate.CertificateType)
+
+	parsed, err := resp.Certificate.X509Certificate()
+	s.Require().NoError(err)
+	s.Assert().True(cert.Equal(parsed))
+
+	_, err = Certificate{CertificateType: CERTIFICATE_TYPE_PGP}.X509Certificate()
+	s.Assert().EqualError(err, "unsupported certificate type PGP")
+}
+
+func TestCryptoSuite(t *testing.T) {
+	suite.Run(t, new(CryptoSuite))
+}
@@ -6,9 +6,10 @@ package kmip
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/binary"
+	"fmt"
 	"io"
-	"io/ioutil"
 	"reflect"
 
 	"github.com/pkg/errors"
@@ -18,11 +19,45 @@ import (
 //
 // Decoding works exactly the same way as encoding
 // (see Encoder documentation), but the other way around.
+//
+// Types implementing Unmarshaler interface are decoded by calling their
+// UnmarshalTTLV method with the decoder limited to the single TTLV item.
+//
+// By default, struct fields are expected to be encoded in the order of
+// declaration, and any unknown field results in an error. In relaxed mode
+// fields are matched by tag regardless of their order, and unknown fields
+// are skipped (or decoded into the field with tag ANY_TAG, if struct
+// has one, e.g. `kmip:"-"` field of type []TTLV collects all unknown fields).
+//
+// Decoder trusts length fields of the message, so when decoding messages
+// from untrusted sources limits (MaxMessageSize, MaxDepth, MaxItems) should
+// be set. Limit violations are reported as Error with result reason
+// RESULT_REASON_RESPONSE_TOO_LARGE.
 type Decoder struct {
+	// Relaxed enables order-insensitive, unknown-field-tolerant decoding
+	Relaxed bool
+
+	// MaxMessageSize limits size of the message in bytes, counted from the beginning
+	// of the top-level item (0 means no limit)
+	MaxMessageSize int
+	// MaxDepth limits nesting depth of structures (0 means no limit)
+	MaxDepth int
+	// MaxItems limits number of TTLV items in the message (0 means no limit)
+	MaxItems int
+
 	r io.Reader
 	s io.ByteScanner
+	c *countingReader
 
 	lastTag Tag
+
+	// offset of the reader start from the beginning of the message
+	base int64
+
+	nested   bool
+	decoding bool
+	depth    int
+	items    *int
 }
 
 // NewDecoder builds Decoder which reads from r
@@ -31,20 +66,125 @@ type Decoder struct {
 // which implements io.ByteScanner
 func NewDecoder(r io.Reader) *Decoder {
 	d := &Decoder{
-		r: r,
+		items: new(int),
 	}
 
-	if s, ok := r.(io.ByteScanner); ok {
-		d.s = s
-	} else {
+	s, ok := r.(io.ByteScanner)
+	if !ok {
 		br := bufio.NewReader(r)
-		d.r = br
-		d.s = br
+		r, s = br, br
 	}
 
+	d.c = &countingReader{r: r, s: s}
+	d.r, d.s = d.c, d.c
+
 	return d
 }
 
+// countingReader counts bytes consumed by the decoder
+//
+// Failure of the underlying reader (other than EOF) is recorded in err,
+// so that it's not reported as decoding error.
+type countingReader struct {
+	r   io.Reader
+	s   io.ByteScanner
+	n   int64
+	err error
+}
+
+func (c *countingReader) Read(p []byte) (n int, err error) {
+	n, err = c.r.Read(p)
+	c.n += int64(n)
+
+	if err != nil && err != io.EOF {
+		c.err = err
+	}
+
+	return
+}
+
+func (c *countingReader) ReadByte() (b byte, err error) {
+	b, err = c.s.ReadByte()
+	if err == nil {
+		c.n++
+	} else if err != io.EOF {
+		c.err = err
+	}
+
+	return
+}
+
+func (c *countingReader) UnreadByte() (err error) {
+	err = c.s.UnreadByte()
+	if err == nil {
+		c.n--
+	}
+
+	return
+}
+
+// offset returns offset of the next item from the beginning of the message
+func (d *Decoder) offset() int64 {
+	offset := d.base + d.c.n
+
+	if d.lastTag != 0 {
+		// tag was already peeked
+		offset -= 3
+	}
+
+	return offset
+}
+
+// child builds Decoder for the nested item with the same settings
+func (d *Decoder) child(r io.Reader) *Decoder {
+	dd := NewDecoder(r)
+	dd.Relaxed = d.Relaxed
+	dd.MaxMessageSize = d.MaxMessageSize
+	dd.MaxDepth = d.MaxDepth
+	dd.MaxItems = d.MaxItems
+	dd.nested = true
+	dd.depth = d.depth
+	dd.items = d.items
+	dd.base = d.offset()
+
+	return dd
+}
+
+// structure builds Decoder for the structure contents of length l
+func (d *Decoder) structure(l uint32) (*Decoder, error) {
+	if d.MaxDepth > 0 && d.depth >= d.MaxDepth {
+		return nil, wrapError(errors.Errorf("message exceeds maximum depth %d", d.MaxDepth), RESULT_REASON_RESPONSE_TOO_LARGE)
+	}
+
+	dd := d.child(io.LimitReader(d.r, int64(l)))
+	dd.depth++
+
+	return dd, nil
+}
+
+// reset prepares top-level decoder for the next message
+//
+// reset returns false if decoder is nested or already decoding a message
+// (e.g. DecodeValue is called from UnmarshalTTLV of top-level value), so that
+// limits are not reset in the middle of the message. If reset returns true,
+// done should be called once the message is decoded.
+func (d *Decoder) reset() bool {
+	if d.nested || d.decoding {
+		return false
+	}
+
+	*d.items = 0
+	d.c.n, d.c.err = 0, nil
+	d.decoding = true
+
+	return true
+}
+
+// done marks the end of the top-level message
+func (d *Decoder) done() {
+	d.decoding = false
+}
+
 func (d *Decoder) internalReadTag() (t Tag, err error) {
 	var b [3]byte
 
@@ -55,6 +195,11 @@ func (d *Decoder) internalReadTag() (t Tag, err error) {
 
 	t = Tag(binary.BigEndian.Uint32(append([]byte{0}, b[:]...)))
 
+	*d.items++
+	if d.MaxItems > 0 && *d.items > d.MaxItems {
+		err = wrapError(errors.Errorf("message exceeds maximum number of items %d", d.MaxItems), RESULT_REASON_RESPONSE_TOO_LARGE)
+	}
+
 	return
 }
 
@@ -86,12 +231,21 @@ func (d *Decoder) expectTag(expected Tag) error {
 	}
 
 	if expected != t && expected != ANY_TAG {
-		return errors.Errorf("expecting tag %x, but %x was encountered", expected, t)
+		return tagMismatchError(expected, t)
 	}
 
 	return nil
 }
 
+// tagMismatchError builds DecodeError for the item with unexpected tag
+func tagMismatchError(expected, t Tag) error {
+	return &DecodeError{
+		ExpectedTag: expected,
+		Tag:         t,
+		Err:         errors.Errorf("expecting tag %x, but %x was encountered", uint32(expected), uint32(t)),
+	}
+}
+
 func (d *Decoder) readType() (t Type, err error) {
 	var b byte
 
@@ -101,6 +255,15 @@ func (d *Decoder) readType() (t Type, err error) {
 	return
 }
 
+func (d *Decoder) peekType() (t Type, err error) {
+	if t, err = d.readType(); err != nil {
+		return
+	}
+
+	err = d.s.UnreadByte()
+	return
+}
+
 func (d *Decoder) expectType(expected Type) error {
 	t, err := d.readType()
 	if err != nil {
@@ -108,7 +271,11 @@ func (d *Decoder) expectType(expected Type) error {
 	}
 
 	if expected != t {
-		return errors.Errorf("expecting type %d, but %d was encountered", expected, t)
+		return &DecodeError{
+			ExpectedType: expected,
+			Type:         t,
+			Err:          errors.Errorf("expecting type %d, but %d was encountered", expected, t),
+		}
 	}
 
 	return nil
@@ -124,6 +291,12 @@ func (d *Decoder) readLength() (l uint32, err error) {
 
 	l = binary.BigEndian.Uint32(b[:])
 
+	// item is checked against the end of the message, so that size of nested items is limited as well
+	if end := d.offset() + int64(l); d.MaxMessageSize > 0 && end > int64(d.MaxMessageSize) {
+		err = wrapError(errors.Errorf("item with length %d ends at offset %d, exceeding maximum message size %d", l, end, d.MaxMessageSize),
+			RESULT_REASON_RESPONSE_TOO_LARGE)
+	}
+
 	return
 }
 
@@ -141,7 +314,25 @@ func (d *Decoder) expectLength(expected uint32) error {
 }
 
 // Decode structure from the reader into v
+//
+// Failures to decode the message are reported as *DecodeError, failures
+// to read from the underlying reader are returned as is.
 func (d *Decoder) Decode(v interface{}) error {
+	if d.reset() {
+		defer d.done()
+	}
+
+	if u, ok := v.(Unmarshaler); ok {
+		offset := d.offset()
+
+		tag, err := d.peekTag()
+		if err != nil {
+			return err
+		}
+
+		return d.rootError(u.UnmarshalTTLV(d, ANY_TAG), tag, offset)
+	}
+
 	rv := reflect.ValueOf(v)
 	if !rv.IsValid() {
 		return errors.New("invalid value")
@@ -161,33 +352,149 @@ func (d *Decoder) Decode(v interface{}) error {
 		return err
 	}
 
+	offset := d.offset()
+
 	_, err = d.decode(rv, structDesc)
-	return err
+
+	return d.rootError(err, structDesc.tag, offset)
+}
+
+// rootError wraps error returned while decoding top-level item
+func (d *Decoder) rootError(err error, tag Tag, offset int64) error {
+	if err == nil || d.nested {
+		return err
+	}
+
+	// clean EOF before the message: no more messages
+	if err == io.EOF && d.offset() == offset {
+		return err
+	}
+
+	// reading failed (e.g. read timeout), message itself might be fine
+	if d.c.err != nil {
+		return errors.Wrap(d.c.err, "error reading message")
+	}
+
+	return wrapDecodeError(err, tagToText(tag), offset)
+}
+
+// DecodeValue decodes single value with the tag t into v
+//
+// v should be a pointer to the value of one of supported types.
+// DecodeValue is useful to implement Unmarshaler interface.
+func (d *Decoder) DecodeValue(t Tag, v interface{}) error {
+	if d.reset() {
+		defer d.done()
+	}
+
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("non-nil pointer value expected")
+	}
+
+	rv = rv.Elem()
+
+	f := field{
+		name: "value",
+		tag:  t,
+	}
+
+	if err := guessType(rv.Type(), &f); err != nil {
+		return err
+	}
+
+	if f.dynamic {
+		return errors.New("dynamic values are not supported")
+	}
+
+	offset := d.offset()
+
+	_, val, err := d.decodeValue(f, rv.Type(), rv)
+	if err != nil {
+		return d.rootError(err, t, offset)
+	}
+
+	rv.Set(reflect.ValueOf(val))
+
+	return nil
+}
+
+// decodeWireType decodes value according to the type on the wire:
+// primitive values are decoded into respective Go types, structures into TTLV
+func (d *Decoder) decodeWireType(tag Tag) (n int, v interface{}, err error) {
+	var t TTLV
+
+	if n, err = t.decode(d, tag); err != nil {
+		return
+	}
+
+	if t.Type == STRUCTURE {
+		v = t
+	} else {
+		v = t.Value
+	}
+
+	return
+}
+
+func (d *Decoder) decodeCustom(tag Tag, t reflect.Type) (n int, v interface{}, err error) {
+	var vv reflect.Value
+
+	if t.Kind() == reflect.Ptr {
+		vv = reflect.New(t.Elem())
+	} else {
+		vv = reflect.New(t)
+	}
+
+	u, ok := vv.Interface().(Unmarshaler)
+	if !ok {
+		err = errors.Errorf("type %s doesn't implement Unmarshaler", t.String())
+		return
+	}
+
+	var raw []byte
+
+	n, raw, err = d.readItem(tag)
+	if err != nil {
+		return
+	}
+
+	// item header is counted once more while decoding raw item
+	*d.items--
+
+	if err = u.UnmarshalTTLV(d.child(bytes.NewReader(raw)), tag); err != nil {
+		return
+	}
+
+	if t.Kind() == reflect.Ptr {
+		v = vv.Interface()
+	} else {
+		v = vv.Elem().Interface()
+	}
+
+	return
 }
 
 func (d *Decoder) decodeValue(f field, t reflect.Type, ff reflect.Value) (n int, v interface{}, err error) {
 	if f.skip {
-		if err = d.expectTag(f.tag); err != nil {
-			return
-		}
-
-		if _, err = d.readType(); err != nil {
-			return
-		}
+		n, err = d.skipItem(f.tag)
+		return
+	}
 
-		var l uint32
-		if l, err = d.readLength(); err != nil {
-			return
-		}
+	if f.custom {
+		return d.decodeCustom(f.tag, t)
+	}
 
-		n = 8
+	if f.ptr {
+		f.ptr = false
 
-		if l%8 != 0 {
-			l += 8 - l%8
+		if n, v, err = d.decodeValue(f, t.Elem(), ff); err != nil {
+			return
 		}
 
-		_, err = io.CopyN(ioutil.Discard, d.r, int64(l))
-		n += int(l)
+		pv := reflect.New(t.Elem())
+		pv.Elem().Set(reflect.ValueOf(v))
+		v = pv.Interface()
 
 		return
 	}
@@ -199,6 +506,8 @@ func (d *Decoder) decodeValue(f field, t reflect.Type, ff reflect.Value) (n int,
 	case LONG_INTEGER:
 		v, err = d.readLongInteger(f.tag)
 		n = 16
+	case BIG_INTEGER:
+		n, v, err = d.readBigInteger(f.tag)
 	case ENUMERATION:
 		v, err = d.readEnum(f.tag)
 		n = 16
@@ -235,30 +544,34 @@ func (d *Decoder) decodeValue(f field, t reflect.Type, ff reflect.Value) (n int,
 				return
 			}
 
+			if val == nil {
+				return d.decodeWireType(f.tag)
+			}
+
 			vv = reflect.ValueOf(val)
 
-			switch vv.Type() {
-			case typeOfInt32:
-				f.typ = INTEGER
-				return d.decodeValue(f, t, ff)
-			case typeOfInt64:
-				f.typ = LONG_INTEGER
-				return d.decodeValue(f, t, ff)
-			case typeOfEnum:
-				f.typ = ENUMERATION
-				return d.decodeValue(f, t, ff)
-			case typeOfBool:
-				f.typ = BOOLEAN
-				return d.decodeValue(f, t, ff)
-			case typeOfBytes:
-				f.typ = BYTE_STRING
-				return d.decodeValue(f, t, ff)
-			case typeOfString:
-				f.typ = TEXT_STRING
-				return d.decodeValue(f, t, ff)
-			case typeOfTime:
-				f.typ = DATE_TIME
-				return d.decodeValue(f, t, ff)
+			vt := vv.Type()
+			if vt.Kind() == reflect.Ptr {
+				vt = vt.Elem()
+			}
+
+			if reflect.PtrTo(vt).Implements(typeOfUnmarshaler) {
+				return d.decodeCustom(f.tag, vt)
+			}
+
+			// primitive values (including named types) are decoded as primitives
+			if vv.Kind() != reflect.Ptr || vv.Type() == typeOfBigInt {
+				vf := f
+				vf.dynamic = false
+
+				if err = guessType(vv.Type(), &vf); err != nil {
+					err = errors.WithMessagef(err, "error processing field %v", f.name)
+					return
+				}
+
+				if vf.typ != STRUCTURE {
+					return d.decodeValue(vf, vv.Type(), ff)
+				}
 			}
 
 			sD, err = getStructDesc(vv.Type().Elem())
@@ -275,12 +588,16 @@ func (d *Decoder) decodeValue(f field, t reflect.Type, ff reflect.Value) (n int,
 			vv = reflect.New(t)
 		}
 
-		sD.tag = f.tag
-		n, err = d.decode(vv, sD)
+		n, err = d.decode(vv, sD.withTag(f.tag))
 
 		v = vv.Elem().Interface()
 	default:
-		panic("unsupported type")
+		err = errors.Errorf("unsupported type for decode, field %v", f.name)
+	}
+
+	// convert to the named type
+	if err == nil && f.typ != STRUCTURE && t.Kind() != reflect.Interface && reflect.TypeOf(v) != t {
+		v = reflect.ValueOf(v).Convert(t).Interface()
 	}
 
 	return
@@ -309,9 +626,23 @@ func (d *Decoder) decode(rv reflect.Value, structD *structDesc) (n int, err erro
 	n += 4
 
 	// initialize wrapped decoder with limited reader
-	dd := NewDecoder(io.LimitReader(d.r, int64(expectedLen)))
+	var dd *Decoder
+	if dd, err = d.structure(expectedLen); err != nil {
+		return
+	}
+
+	if d.Relaxed {
+		var nn int
+
+		nn, err = dd.decodeRelaxed(rv, structD, expectedLen)
+		n += nn
+
+		return
+	}
 
 	for _, f := range structD.fields {
+		offset := dd.offset()
+
 		var tag Tag
 		tag, err = dd.peekTag()
 
@@ -321,7 +652,7 @@ func (d *Decoder) decode(rv reflect.Value, structD *structDesc) (n int, err erro
 		}
 
 		if err != nil {
-			err = errors.Wrapf(err, "error reading field %v", f.name)
+			err = wrapDecodeError(err, peekSegment(f, tag), offset)
 			return
 		}
 
@@ -339,10 +670,10 @@ func (d *Decoder) decode(rv reflect.Value, structD *structDesc) (n int, err erro
 		if f.sliceof {
 			ff.Set(reflect.MakeSlice(ff.Type(), 0, 0))
 
-			for {
+			for i := 0; ; i++ {
 				nn, v, err = dd.decodeValue(f, ff.Type().Elem(), rv)
 				if err != nil {
-					err = errors.Wrapf(err, "error reading field %v", f.name)
+					err = wrapDecodeError(err, pathSegment(f, tag, i), offset)
 					return
 				}
 
@@ -357,8 +688,11 @@ func (d *Decoder) decode(rv reflect.Value, structD *structDesc) (n int, err erro
 					break
 				}
 
+				offset = dd.offset()
+
 				tag, err = dd.peekTag()
 				if err != nil {
+					err = wrapDecodeError(err, peekSegment(f, tag), offset)
 					return
 				}
 
@@ -369,7 +703,7 @@ func (d *Decoder) decode(rv reflect.Value, structD *structDesc) (n int, err erro
 		} else {
 			nn, v, err = dd.decodeValue(f, ff.Type(), rv)
 			if err != nil {
-				err = errors.Wrapf(err, "error reading field %v", f.name)
+				err = wrapDecodeError(err, pathSegment(f, tag, 0), offset)
 				return
 			}
 
@@ -383,7 +717,132 @@ func (d *Decoder) decode(rv reflect.Value, structD *structDesc) (n int, err erro
 	}
 
 	if actualLen != expectedLen {
-		err = errors.Errorf("error reading structure expected %d != actual %d", expectedLen, actualLen)
+		err = &DecodeError{
+			Err:    errors.Errorf("error reading structure expected %d != actual %d", expectedLen, actualLen),
+			reason: RESULT_REASON_INVALID_MESSAGE,
+		}
+	}
+
+	return
+}
+
+// peekSegment builds DecodeError path segment for the failure to read the next tag
+//
+// Tag might be not known (e.g. on EOF), so the field being decoded is used instead.
+func peekSegment(f field, tag Tag) string {
+	if tag == 0 {
+		tag = f.tag
+	}
+
+	return tagToText(tag)
+}
+
+// pathSegment builds DecodeError path segment for the field
+func pathSegment(f field, tag Tag, idx int) string {
+	if f.tag != ANY_TAG {
+		tag = f.tag
+	}
+
+	if f.sliceof {
+		return fmt.Sprintf("%s[%d]", tagToText(tag), idx)
+	}
+
+	return tagToText(tag)
+}
+
+func (d *Decoder) decodeRelaxed(rv reflect.Value, structD *structDesc, expectedLen uint32) (n int, err error) {
+	// number of occurrences of each field
+	counts := make([]int, len(structD.fields))
+
+	for uint32(n) < expectedLen {
+		offset := d.offset()
+
+		var tag Tag
+		tag, err = d.peekTag()
+		if err != nil {
+			err = wrapDecodeError(err, tagToText(tag), offset)
+			return
+		}
+
+		idx := -1
+
+		for i := range structD.fields {
+			if structD.fields[i].tag == tag {
+				idx = i
+				break
+			}
+		}
+
+		if idx == -1 {
+			for i := range structD.fields {
+				if structD.fields[i].tag == ANY_TAG {
+					idx = i
+					break
+				}
+			}
+		}
+
+		var nn int
+
+		if idx == -1 {
+			nn, err = d.skipItem(tag)
+			if err != nil {
+				err = wrapDecodeError(err, tagToText(tag), offset)
+				return
+			}
+
+			n += nn
+			continue
+		}
+
+		f := structD.fields[idx]
+		ff := rv.FieldByIndex(f.idx)
+
+		var v interface{}
+
+		if f.sliceof {
+			nn, v, err = d.decodeValue(f, ff.Type().Elem(), rv)
+		} else {
+			nn, v, err = d.decodeValue(f, ff.Type(), rv)
+		}
+
+		if err != nil {
+			err = wrapDecodeError(err, pathSegment(f, tag, counts[idx]), offset)
+			return
+		}
+
+		n += nn
+
+		if !f.skip {
+			if f.sliceof {
+				if counts[idx] == 0 {
+					ff.Set(reflect.MakeSlice(ff.Type(), 0, 0))
+				}
+
+				ff.Set(reflect.Append(ff, reflect.ValueOf(v)))
+			} else {
+				ff.Set(reflect.ValueOf(v))
+			}
+		}
+
+		counts[idx]++
+	}
+
+	if uint32(n) != expectedLen {
+		err = &DecodeError{
+			Err:    errors.Errorf("error reading structure expected %d != actual %d", expectedLen, n),
+			reason: RESULT_REASON_INVALID_MESSAGE,
+		}
+		return
+	}
+
+	for i, f := range structD.fields {
+		if f.required && counts[i] == 0 {
+			err = &DecodeError{
+				Err: errors.Errorf("missing required field %v", f.name),
+			}
+			return
+		}
 	}
 
 	return
@@ -5,8 +5,11 @@ package kmip
  * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
 
 import (
+	"bytes"
 	"encoding/binary"
 	"io"
+	"io/ioutil"
+	"math/big"
 	"time"
 
 	"github.com/pkg/errors"
@@ -62,6 +65,58 @@ func (d *Decoder) readLongInteger(expectedTag Tag) (v int64, err error) {
 	return
 }
 
+func (d *Decoder) readBigInteger(expectedTag Tag) (n int, v *big.Int, err error) {
+	n, v, _, err = d.readBigIntegerRaw(expectedTag)
+	return
+}
+
+// readBigIntegerRaw reads Big Integer returning both decoded value and its encoding
+func (d *Decoder) readBigIntegerRaw(expectedTag Tag) (n int, v *big.Int, b []byte, err error) {
+	if err = d.expectTag(expectedTag); err != nil {
+		return
+	}
+
+	if err = d.expectType(BIG_INTEGER); err != nil {
+		return
+	}
+
+	var l uint32
+	if l, err = d.readLength(); err != nil {
+		return
+	}
+
+	if l == 0 || l%8 != 0 {
+		err = errors.Errorf("unexpected big integer length: %d", l)
+		return
+	}
+
+	b = make([]byte, l)
+	_, err = io.ReadFull(d.r, b)
+	if err != nil {
+		return
+	}
+
+	n = int(l) + 8
+	v = bigIntFromTwosComplement(b)
+
+	return
+}
+
+// bigIntFromTwosComplement decodes two's complement big-endian encoding b
+func bigIntFromTwosComplement(b []byte) *big.Int {
+	v := new(big.Int).SetBytes(b)
+
+	// two's complement, negative value
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		var c big.Int
+
+		c.Lsh(big.NewInt(1), uint(len(b)*8))
+		v.Sub(v, &c)
+	}
+
+	return v
+}
+
 func (d *Decoder) readEnum(expectedTag Tag) (v Enum, err error) {
 	if err = d.expectTag(expectedTag); err != nil {
 		return
@@ -161,6 +216,99 @@ func (d *Decoder) readByteSlice(expectedTag Tag, expectedType Type) (n int, v []
 	return
 }
 
+const (
+	maxInt = int(^uint(0) >> 1)
+
+	// maxItemPrealloc limits buffer preallocated for the item by readItem
+	maxItemPrealloc = 64 * 1024
+)
+
+// paddedLength returns item value length padded to the multiple of 8 bytes
+//
+// Padding is calculated in int64, as it overflows uint32 for lengths close to 4 GiB.
+func paddedLength(l uint32) int64 {
+	return (int64(l) + 7) &^ 7
+}
+
+func (d *Decoder) readItem(expectedTag Tag) (n int, v []byte, err error) {
+	var t Tag
+	if t, err = d.readTag(); err != nil {
+		return
+	}
+
+	if expectedTag != t && expectedTag != ANY_TAG {
+		err = tagMismatchError(expectedTag, t)
+		return
+	}
+
+	var typ Type
+	if typ, err = d.readType(); err != nil {
+		return
+	}
+
+	var l uint32
+	if l, err = d.readLength(); err != nil {
+		return
+	}
+
+	padded := paddedLength(l)
+	if padded > int64(maxInt)-8 {
+		err = errors.Errorf("item length %d is too large", l)
+		return
+	}
+
+	// buffer grows as the data is read, so that bogus length doesn't cause huge allocation
+	size := padded
+	if size > maxItemPrealloc {
+		size = maxItemPrealloc
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 8, 8+int(size)))
+
+	b := buf.Bytes()
+	b[0], b[1], b[2] = byte(t>>16), byte(t>>8), byte(t)
+	b[3] = byte(typ)
+	binary.BigEndian.PutUint32(b[4:8], l)
+
+	_, err = io.CopyN(buf, d.r, padded)
+	if err != nil {
+		return
+	}
+
+	v = buf.Bytes()
+	n = len(v)
+
+	return
+}
+
+func (d *Decoder) skipItem(expectedTag Tag) (n int, err error) {
+	if err = d.expectTag(expectedTag); err != nil {
+		return
+	}
+
+	if _, err = d.readType(); err != nil {
+		return
+	}
+
+	var l uint32
+	if l, err = d.readLength(); err != nil {
+		return
+	}
+
+	padded := paddedLength(l)
+	if padded > int64(maxInt)-8 {
+		err = errors.Errorf("item length %d is too large", l)
+		return
+	}
+
+	n = 8
+
+	_, err = io.CopyN(ioutil.Discard, d.r, padded)
+	n += int(padded)
+
+	return
+}
+
 func (d *Decoder) readBytes(expectedTag Tag) (n int, v []byte, err error) {
 	n, v, err = d.readByteSlice(expectedTag, BYTE_STRING)
 	return
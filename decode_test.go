@@ -7,10 +7,14 @@ package kmip
 import (
 	"bytes"
 	"encoding/hex"
+	"io"
+	"math/big"
+	"net"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -68,6 +72,20 @@ func (s *DecoderSuite) TestReadLongInteger() {
 	s.Assert().EqualValues(123456789000000000, v)
 }
 
+func (s *DecoderSuite) TestReadBigInteger() {
+	n, v, err := NewDecoder(bytes.NewReader(s.parseSpecValue("42 00 20 | 04 | 00 00 00 10 | 00 00 00 00 03 FD 35 EB 6B C2 DF 46 18 08 00 00"))).readBigInteger(COMPROMISE_DATE)
+	s.Assert().NoError(err)
+	s.Assert().Equal("1234567890000000000000000000", v.String())
+	s.Assert().EqualValues(24, n)
+
+	_, v, err = NewDecoder(bytes.NewReader(s.parseSpecValue("42 00 20 | 04 | 00 00 00 08 | FF FF FF FF FF FF FF 7F"))).readBigInteger(COMPROMISE_DATE)
+	s.Assert().NoError(err)
+	s.Assert().EqualValues(-129, v.Int64())
+
+	_, _, err = NewDecoder(bytes.NewReader(s.parseSpecValue("42 00 20 | 04 | 00 00 00 04 | FF FF FF 7F 00 00 00 00"))).readBigInteger(COMPROMISE_DATE)
+	s.Assert().EqualError(err, "unexpected big integer length: 4")
+}
+
 func (s *DecoderSuite) TestReadEnum() {
 	v, err := NewDecoder(bytes.NewReader(s.parseSpecValue("42 00 20 | 05 | 00 00 00 04 | 00 00 00 FF 00 00 00 00"))).readEnum(COMPROMISE_DATE)
 	s.Assert().NoError(err)
@@ -104,6 +122,17 @@ func (s *DecoderSuite) TestReadBytes() {
 	s.Assert().EqualValues(16, n)
 }
 
+func (s *DecoderSuite) TestReadItemHugeLength() {
+	// padding of the length 0xFFFFFFFA overflows uint32
+	data := s.parseSpecValue("42 00 20 | 08 | FF FF FF FA | 01 02 03 00 00 00 00 00")
+
+	_, err := NewDecoder(bytes.NewReader(data)).skipItem(COMPROMISE_DATE)
+	s.Assert().Equal(io.EOF, err)
+
+	_, _, err = NewDecoder(bytes.NewReader(data)).readItem(COMPROMISE_DATE)
+	s.Assert().Equal(io.EOF, err)
+}
+
 func (s *DecoderSuite) TestReadTime() {
 	v, err := NewDecoder(bytes.NewReader(s.parseSpecValue("42 00 20 | 09 | 00 00 00 08 | 00 00 00 00 47 DA 67 F8"))).readTime(COMPROMISE_DATE)
 	s.Assert().NoError(err)
@@ -136,6 +165,47 @@ func (s *DecoderSuite) TestDecodeStruct() {
 	s.Assert().EqualValues(255, v.B)
 }
 
+func (s *DecoderSuite) TestDecodeStructWithBigInteger() {
+	type tt struct {
+		Tag `kmip:"COMPROMISE_DATE"`
+		A   *big.Int `kmip:"MODULUS"`
+		B   *big.Int `kmip:"PRIVATE_EXPONENT"`
+	}
+
+	var v tt
+
+	err := NewDecoder(bytes.NewReader(s.parseSpecValue("42 00 20 | 01 | 00 00 00 10 | 42 00 52 | 04 | 00 00 00 08 | 00 00 00 00 00 01 00 01"))).Decode(&v)
+	s.Assert().NoError(err)
+
+	s.Assert().EqualValues(0x10001, v.A.Int64())
+	s.Assert().Nil(v.B)
+}
+
+func (s *DecoderSuite) TestDecodeStructCustom() {
+	type tt struct {
+		Tag `kmip:"COMPROMISE_DATE"`
+		A   testMask   `kmip:"CRYPTOGRAPHIC_USAGE_MASK"`
+		B   []testMask `kmip:"ARCHIVE_DATE"`
+		C   testMask   `kmip:"ACTIVATION_DATE"`
+	}
+
+	var v tt
+
+	err := NewDecoder(bytes.NewReader(s.parseSpecValue("42 00 20 | 01 | 00 00 00 30 | 42 00 2C | 02 | 00 00 00 04 | 00 00 00 0C 00 00 00 00 |" +
+		" 42 00 05 | 02 | 00 00 00 04 | 00 00 00 FE 00 00 00 00 | 42 00 05 | 02 | 00 00 00 04 | 00 00 00 FF 00 00 00 00"))).Decode(&v)
+	s.Assert().NoError(err)
+
+	s.Assert().EqualValues(12, v.A)
+	s.Assert().EqualValues([]testMask{254, 255}, v.B)
+	s.Assert().EqualValues(0, v.C)
+
+	var m testMask
+
+	err = NewDecoder(bytes.NewReader(s.parseSpecValue("42 00 20 | 02 | 00 00 00 04 | 00 00 00 08 00 00 00 00"))).Decode(&m)
+	s.Assert().NoError(err)
+	s.Assert().EqualValues(8, m)
+}
+
 func (s *DecoderSuite) TestDecodeStructSkip() {
 	type tt struct {
 		Tag   `kmip:"COMPROMISE_DATE"`
@@ -171,6 +241,106 @@ func (s *DecoderSuite) TestDecodeStructSkipAny() {
 	s.Assert().EqualValues(nil, v.B)
 }
 
+func (s *DecoderSuite) TestDecodeStructRelaxed() {
+	type tt struct {
+		Tag `kmip:"COMPROMISE_DATE"`
+		A   Enum   `kmip:"APPLICATION_SPECIFIC_INFORMATION,required"`
+		B   int32  `kmip:"ARCHIVE_DATE,required"`
+		C   []Enum `kmip:"ACTIVATION_DATE"`
+	}
+
+	// B before A, C is not contiguous, unknown tag 42 00 06 in the middle
+	data := s.parseSpecValue("42 00 20 | 01 | 00 00 00 58 | 42 00 05 | 02 | 00 00 00 04 | 00 00 00 FF 00 00 00 00 |" +
+		" 42 00 01 | 05 | 00 00 00 04 | 00 00 00 01 00 00 00 00 |" +
+		" 42 00 06 | 07 | 00 00 00 0B | 48 65 6C 6C 6F 20 57 6F 72 6C 64 00 00 00 00 00 |" +
+		" 42 00 04 | 05 | 00 00 00 04 | 00 00 00 FE 00 00 00 00 |" +
+		" 42 00 01 | 05 | 00 00 00 04 | 00 00 00 02 00 00 00 00")
+
+	var v tt
+
+	err := NewDecoder(bytes.NewReader(data)).Decode(&v)
+	s.Assert().EqualError(err, "error decoding CompromiseDate/ApplicationSpecificInformation at offset 8: expecting tag 420004, but 420005 was encountered")
+
+	d := NewDecoder(bytes.NewReader(data))
+	d.Relaxed = true
+
+	v = tt{}
+	err = d.Decode(&v)
+	s.Assert().NoError(err)
+	s.Assert().Equal(tt{A: 254, B: 255, C: []Enum{1, 2}}, v)
+
+	type tt2 struct {
+		Tag   `kmip:"COMPROMISE_DATE"`
+		A     Enum   `kmip:"APPLICATION_SPECIFIC_INFORMATION,required"`
+		B     int32  `kmip:"ARCHIVE_DATE,required"`
+		Extra []TTLV `kmip:"-"`
+	}
+
+	d = NewDecoder(bytes.NewReader(data))
+	d.Relaxed = true
+
+	var v2 tt2
+	err = d.Decode(&v2)
+	s.Assert().NoError(err)
+	s.Assert().Equal(tt2{A: 254, B: 255, Extra: []TTLV{
+		{Tag: ACTIVATION_DATE, Type: ENUMERATION, Value: Enum(1)},
+		{Tag: ASYNCHRONOUS_CORRELATION_VALUE, Type: TEXT_STRING, Value: "Hello World"},
+		{Tag: ACTIVATION_DATE, Type: ENUMERATION, Value: Enum(2)},
+	}}, v2)
+
+	type tt3 struct {
+		Tag `kmip:"COMPROMISE_DATE"`
+		A   Enum `kmip:"APPLICATION_SPECIFIC_INFORMATION,required"`
+		D   bool `kmip:"ASYNCHRONOUS_INDICATOR,required"`
+	}
+
+	d = NewDecoder(bytes.NewReader(data))
+	d.Relaxed = true
+
+	var v3 tt3
+	err = d.Decode(&v3)
+	s.Assert().EqualError(err, "error decoding CompromiseDate at offset 0: missing required field D")
+}
+
+func (s *DecoderSuite) TestDecodeStructOptional() {
+	data := s.parseSpecValue("42 00 20 | 01 | 00 00 00 68 |" +
+		" 42 00 2A | 02 | 00 00 00 04 | 00 00 00 00 00 00 00 00 |" +
+		" 42 00 D7 | 06 | 00 00 00 08 | 00 00 00 00 00 00 00 00 |" +
+		" 42 00 2C | 02 | 00 00 00 04 | 80 00 00 01 00 00 00 00 |" +
+		" 42 00 55 | 07 | 00 00 00 03 | 6B 65 79 00 00 00 00 00 |" +
+		" 42 00 69 | 01 | 00 00 00 00 |" +
+		" 42 00 A8 | 06 | 00 00 00 08 | 00 00 00 00 00 00 00 01 |" +
+		" 42 00 A8 | 06 | 00 00 00 08 | 00 00 00 00 00 00 00 00")
+
+	for _, relaxed := range []bool{false, true} {
+		var v testOptional
+
+		d := NewDecoder(bytes.NewReader(data))
+		d.Relaxed = relaxed
+
+		s.Require().NoError(d.Decode(&v))
+
+		s.Require().NotNil(v.Length)
+		s.Assert().EqualValues(0, *v.Length)
+		s.Require().NotNil(v.Init)
+		s.Assert().False(*v.Init)
+		s.Assert().Equal(testFlags(0x80000001), v.Mask)
+		s.Assert().Equal(testName("key"), v.Name)
+		s.Assert().Equal(&ProtocolVersion{}, v.Version)
+		s.Require().Len(v.Fresh, 2)
+		s.Assert().True(*v.Fresh[0])
+		s.Assert().False(*v.Fresh[1])
+	}
+
+	// absent fields stay nil
+	var v testOptional
+
+	s.Require().NoError(NewDecoder(bytes.NewReader(s.parseSpecValue("42 00 20 | 01 | 00 00 00 00"))).Decode(&v))
+	s.Assert().Nil(v.Length)
+	s.Assert().Nil(v.Init)
+	s.Assert().Nil(v.Version)
+}
+
 func (s *DecoderSuite) TestDecodeMessageCreate() {
 	var m Request
 
@@ -199,7 +369,7 @@ func (s *DecoderSuite) TestDecodeMessageCreate() {
 								},
 								{
 									Name:  ATTRIBUTE_NAME_CRYPTOGRAPHIC_USAGE_MASK,
-									Value: int32(12),
+									Value: CRYPTO_USAGE_MASK_ENCRYPT | CRYPTO_USAGE_MASK_DECRYPT,
 								},
 								{
 									Name:  ATTRIBUTE_NAME_INITIAL_DATE,
@@ -234,6 +404,173 @@ func (s *DecoderSuite) TestDecodeMessageGet() {
 	}, m)
 }
 
+// testRequestPair is decoded from two consecutive messages with DecodeValue
+type testRequestPair [2]Request
+
+func (p *testRequestPair) UnmarshalTTLV(d *Decoder, tag Tag) error {
+	for i := range p {
+		if err := d.DecodeValue(REQUEST_MESSAGE, &p[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *DecoderSuite) TestDecodeLimits() {
+	decode := func(maxMessageSize, maxDepth, maxItems int, v interface{}) error {
+		d := NewDecoder(bytes.NewReader(messageGet))
+		d.MaxMessageSize = maxMessageSize
+		d.MaxDepth = maxDepth
+		d.MaxItems = maxItems
+
+		return d.Decode(v)
+	}
+
+	// messageGet: 152 bytes, depth 3, 10 items
+	var m Request
+	s.Assert().NoError(decode(152, 3, 10, &m))
+
+	var t TTLV
+	s.Assert().NoError(decode(152, 3, 10, &t))
+
+	for _, v := range []interface{}{&Request{}, &TTLV{}} {
+		err := decode(151, 0, 0, v)
+		s.Assert().EqualError(err, "error decoding RequestMessage at offset 0: item with length 144 ends at offset 152, exceeding maximum message size 151")
+		s.Assert().Equal(RESULT_REASON_RESPONSE_TOO_LARGE, errors.Cause(err).(Error).ResultReason())
+
+		err = decode(0, 2, 0, v)
+		s.Assert().Contains(err.Error(), "message exceeds maximum depth 2")
+		s.Assert().Equal(RESULT_REASON_RESPONSE_TOO_LARGE, errors.Cause(err).(Error).ResultReason())
+
+		err = decode(0, 0, 9, v)
+		s.Assert().Contains(err.Error(), "message exceeds maximum number of items 9")
+		s.Assert().Equal(RESULT_REASON_RESPONSE_TOO_LARGE, errors.Cause(err).(Error).ResultReason())
+	}
+
+	// nested item length is checked before allocation
+	d := NewDecoder(bytes.NewReader(s.parseSpecValue("42002001000000104200050800ffffff")))
+	d.MaxMessageSize = 1024
+	s.Assert().EqualError(d.Decode(&t), "error decoding CompromiseDate/ArchiveDate at offset 8: item with length 16777215 ends at offset 16777231, exceeding maximum message size 1024")
+
+	// limits are not reset when top-level Unmarshaler decodes values
+	var pair testRequestPair
+
+	d = NewDecoder(bytes.NewReader(append(append([]byte(nil), messageGet...), messageGet...)))
+	d.MaxItems = 10
+	err := d.Decode(&pair)
+	s.Require().Error(err)
+	s.Assert().Contains(err.Error(), "message exceeds maximum number of items 10")
+
+	d = NewDecoder(bytes.NewReader(append(append([]byte(nil), messageGet...), messageGet...)))
+	d.MaxItems = 20
+	s.Assert().NoError(d.Decode(&pair))
+
+	// limits on number of items and size are applied per message
+	d = NewDecoder(bytes.NewReader(append(append([]byte(nil), messageGet...), messageGet...)))
+	d.MaxItems = 10
+	d.MaxMessageSize = 152
+	s.Assert().NoError(d.Decode(&m))
+	s.Assert().NoError(d.Decode(&m))
+}
+
+func (s *DecoderSuite) TestDecodeError() {
+	var m Request
+
+	// truncated message
+	err := NewDecoder(bytes.NewReader(messageCreate[:200])).Decode(&m)
+	s.Require().IsType(&DecodeError{}, err)
+
+	de := err.(*DecodeError)
+	s.Assert().Equal("RequestMessage/BatchItem[0]/RequestPayload/TemplateAttribute/Attribute[1]/AttributeName", de.Path)
+	s.Assert().EqualValues(192, de.Offset)
+	s.Assert().Equal(RESULT_REASON_INVALID_MESSAGE, de.ResultReason())
+	s.Assert().Equal(io.EOF, errors.Cause(err))
+
+	// Operation has wrong tag
+	message := append([]byte(nil), messageGet...)
+	message[82] = 0x5d
+
+	err = NewDecoder(bytes.NewReader(message)).Decode(&m)
+	s.Require().IsType(&DecodeError{}, err)
+
+	de = err.(*DecodeError)
+	s.Assert().Equal("RequestMessage/BatchItem[0]/Operation", de.Path)
+	s.Assert().EqualValues(80, de.Offset)
+	s.Assert().Equal(OPERATION, de.ExpectedTag)
+	s.Assert().Equal(Tag(0x42005d), de.Tag)
+	s.Assert().Equal(RESULT_REASON_INVALID_FIELD, de.ResultReason())
+	s.Assert().EqualError(err, "error decoding RequestMessage/BatchItem[0]/Operation at offset 80: expecting tag 42005c, but 42005d was encountered")
+
+	// custom type (Unmarshaler) with wrong tag
+	var batchItem struct {
+		Tag `kmip:"REQUEST_MESSAGE"`
+
+		Item TTLV `kmip:"BATCH_ITEM,required"`
+	}
+
+	err = NewDecoder(bytes.NewReader(messageGet)).Decode(&batchItem)
+	s.Require().IsType(&DecodeError{}, err)
+
+	de = err.(*DecodeError)
+	s.Assert().Equal("RequestMessage/BatchItem", de.Path)
+	s.Assert().Equal(BATCH_ITEM, de.ExpectedTag)
+	s.Assert().Equal(REQUEST_HEADER, de.Tag)
+	s.Assert().Equal(RESULT_REASON_INVALID_FIELD, de.ResultReason())
+
+	// generic TTLV decoding
+	var t TTLV
+
+	message = append([]byte(nil), messageGet...)
+	message[107] = byte(BOOLEAN)
+
+	err = NewDecoder(bytes.NewReader(message)).Decode(&t)
+	s.Require().IsType(&DecodeError{}, err)
+	s.Assert().Equal(RESULT_REASON_INVALID_FIELD, err.(*DecodeError).ResultReason())
+	s.Assert().EqualError(err, "error decoding RequestMessage/BatchItem/RequestPayload/UniqueIdentifier at offset 104: expecting length 8, but 36 was encountered")
+
+	// clean EOF is not wrapped
+	s.Assert().Equal(io.EOF, NewDecoder(bytes.NewReader(nil)).Decode(&m))
+	s.Assert().Equal(io.EOF, NewDecoder(bytes.NewReader(nil)).Decode(&t))
+}
+
+func (s *DecoderSuite) TestDecodeReadError() {
+	client, server := net.Pipe()
+	defer client.Close() //nolint:errcheck
+	defer server.Close() //nolint:errcheck
+
+	go func() {
+		_, _ = client.Write(messageGet[:50])
+	}()
+
+	s.Require().NoError(server.SetReadDeadline(time.Now().Add(100 * time.Millisecond)))
+
+	var m Request
+
+	// read timeout in the middle of the message is not a decoding failure
+	err := NewDecoder(server).Decode(&m)
+	s.Require().Error(err)
+
+	var protoErr Error
+	s.Assert().False(errors.As(err, &protoErr))
+
+	var netErr net.Error
+	s.Require().True(errors.As(err, &netErr))
+	s.Assert().True(netErr.Timeout())
+}
+
 func TestDecoderSuite(t *testing.T) {
 	suite.Run(t, new(DecoderSuite))
 }
+
+func BenchmarkDecodeMessageCreate(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		var m Request
+
+		if err := NewDecoder(bytes.NewReader(messageCreate)).Decode(&m); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
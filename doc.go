@@ -6,7 +6,12 @@
 // KMIP is using TTLV-like encoding, which is implemented in this packaged
 // as encoding/decoding of Go struct types. Go struct fields are annotated with
 // `kmip` tags which specify KMIP tag names. Field is encoded/decoded according
-// to its tag, type.
+// to its tag, type. Custom Go types might implement Marshaler and Unmarshaler
+// interfaces to control their own encoding. Besides binary TTLV, KMIP JSON
+// and XML encodings are supported via JSONEncoder/JSONDecoder and
+// XMLEncoder/XMLDecoder. Raw TTLV messages can be rendered in human-readable
+// form with Dump for debugging. Vendor extension tags and enumeration values
+// can be registered with RegisterTag and RegisterEnum.
 //
 // Two high-level objects are implemented: Server and Client. Server listens for
 // TLS connections, does initial handshake and processes batch requests from the
@@ -14,9 +19,30 @@
 // Client objects establishes connection with the KMIP server and allows sending
 // any number of requests over the connection.
 //
-// Not all the KMIP operations have corresponding Go structs, missing ones should
-// be added to operations.go, and dynamic type dispatch to
-// RequestBatchItem/ResponseBatchItem.BuildFieldValue methods.
+// Not all the KMIP operations have corresponding Go structs, missing ones (as well
+// as vendor-specific operations) can be registered with RegisterOperation from
+// outside of the package. Attribute values, credential types and key material
+// of key formats are dispatched the same way via RegisterAttribute,
+// RegisterCredentialType and RegisterKeyMaterial.
+//
+// Cryptographic Usage Mask attribute values are decoded as UsageMask, and
+// CRYPTO_USAGE_MASK_* constants are of type UsageMask. Earlier versions used
+// int32 values and Enum constants, so type assertions like attr.Value.(int32)
+// no longer match and should be replaced with Attributes.GetUsageMask, which
+// accepts both int32 and UsageMask values.
+//
+// KeyValue.KeyMaterial is interface{} holding []byte or one of Transparent*
+// key material structures depending on the key format type. Earlier versions
+// declared it as []byte, so such code should be changed to KeyMaterial.([]byte).
+//
+// Register* functions are not safe to be called concurrently with encoding or
+// decoding, so they should be called during initialization (e.g. from init()).
+//
+// Key and certificate objects can be converted to and from Go crypto types
+// with NewSymmetricKey, NewPublicKey, NewPrivateKey, NewCertificate and
+// respective methods of SymmetricKey, PublicKey, PrivateKey and Certificate.
+// Signer implements crypto.Signer and crypto.Decrypter with the private key held
+// by the KMIP server.
 package kmip
 
 /* This Source Code Form is subject to the terms of the Mozilla Public
@@ -0,0 +1,165 @@
+package kmip
+
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Printer renders TTLV byte stream as human-readable indented tree
+//
+// Each item is printed on a separate line with tag name, type, length
+// and decoded value, nested structures are indented:
+//
+//	REQUEST_MESSAGE (0x420078) STRUCTURE [144]
+//	  REQUEST_HEADER (0x420077) STRUCTURE [56]
+//	    PROTOCOL_VERSION (0x420069) STRUCTURE [32]
+//	      PROTOCOL_VERSION_MAJOR (0x42006a) INTEGER [4]: 1
+//	...
+//	    OPERATION (0x42005c) ENUMERATION [4]: GET (0x0000000a)
+type Printer struct {
+	w io.Writer
+
+	// Prefix is printed at the beginning of every line
+	Prefix string
+	// Indent is used to indent nested items, defaults to two spaces
+	Indent string
+}
+
+// NewPrinter builds Printer which writes to w
+func NewPrinter(w io.Writer) *Printer {
+	return &Printer{
+		w:      w,
+		Indent: "  ",
+	}
+}
+
+// Dump renders TTLV byte stream data to w
+//
+// Dump is a shortcut for NewPrinter(w).Print(data).
+func Dump(w io.Writer, data []byte) error {
+	return NewPrinter(w).Print(data)
+}
+
+// Print renders all the TTLV items in data
+//
+// If data is malformed, all the items decoded so far are printed
+// and error is returned.
+func (p *Printer) Print(data []byte) error {
+	return p.print(data, 0, 0)
+}
+
+// print renders items in data, offset is the position of data in the whole message
+//
+// Items are printed as soon as their header is read, so contents of
+// truncated structures are printed up to the point of truncation.
+func (p *Printer) print(data []byte, offset, depth int) error {
+	for pos := 0; pos < len(data); {
+		if len(data)-pos < 8 {
+			return errors.Errorf("error reading item at offset %d: truncated item header", offset+pos)
+		}
+
+		tag := Tag(uint32(data[pos])<<16 | uint32(data[pos+1])<<8 | uint32(data[pos+2]))
+		typ := Type(data[pos+3])
+		l := binary.BigEndian.Uint32(data[pos+4 : pos+8])
+
+		header := p.header(tag, typ, l, depth)
+
+		padded := paddedLength(l)
+		if typ == STRUCTURE {
+			padded = int64(l)
+		}
+
+		remaining := len(data) - pos - 8
+		truncated := padded > int64(remaining)
+
+		if typ == STRUCTURE {
+			if _, err := fmt.Fprintln(p.w, header); err != nil {
+				return err
+			}
+
+			end := remaining
+			if !truncated {
+				end = int(l)
+			}
+
+			if err := p.print(data[pos+8:pos+8+end], offset+pos+8, depth+1); err != nil {
+				return err
+			}
+		} else {
+			if truncated {
+				if _, err := fmt.Fprintf(p.w, "%s: %x\n", header, data[pos+8:]); err != nil {
+					return err
+				}
+			} else if err := p.printValue(data[pos:pos+8+int(padded)], tag, header); err != nil {
+				return err
+			}
+		}
+
+		if truncated {
+			return errors.Errorf("error reading item at offset %d: padded item length %d exceeds remaining %d bytes", offset+pos, padded, remaining)
+		}
+
+		pos += 8 + int(padded)
+	}
+
+	return nil
+}
+
+func (p *Printer) header(tag Tag, typ Type, l uint32, depth int) string {
+	name := tag.String()
+	if _, ok := tagNames[tag]; ok {
+		name = fmt.Sprintf("%s (0x%06x)", name, uint32(tag))
+	}
+
+	return fmt.Sprintf("%s%s%s %s [%d]", p.Prefix, strings.Repeat(p.Indent, depth), name, typ, l)
+}
+
+func (p *Printer) printValue(raw []byte, tag Tag, header string) error {
+	var t TTLV
+
+	if _, err := t.decode(NewDecoder(bytes.NewReader(raw)), tag); err != nil {
+		if _, werr := fmt.Fprintf(p.w, "%s: %x\n", header, raw[8:]); werr != nil {
+			return werr
+		}
+
+		return errors.Wrapf(err, "error decoding tag %s", tag)
+	}
+
+	_, err := fmt.Fprintf(p.w, "%s: %s\n", header, formatValue(t))
+
+	return err
+}
+
+// formatValue renders primitive TTLV value as text
+func formatValue(t TTLV) string {
+	switch v := t.Value.(type) {
+	case Enum:
+		if name, ok := enumNames[t.Tag][v]; ok {
+			return fmt.Sprintf("%s (0x%08x)", name, uint32(v))
+		}
+
+		return fmt.Sprintf("0x%08x", uint32(v))
+	case *big.Int:
+		return v.String()
+	case []byte:
+		return hex.EncodeToString(v)
+	case string:
+		return fmt.Sprintf("%q", v)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,110 @@
+package kmip
+
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DumpSuite struct {
+	suite.Suite
+}
+
+func (s *DumpSuite) TestDumpMessageGet() {
+	var buf bytes.Buffer
+
+	s.Require().NoError(Dump(&buf, messageGet))
+	s.Assert().Equal(`REQUEST_MESSAGE (0x420078) STRUCTURE [144]
+  REQUEST_HEADER (0x420077) STRUCTURE [56]
+    PROTOCOL_VERSION (0x420069) STRUCTURE [32]
+      PROTOCOL_VERSION_MAJOR (0x42006a) INTEGER [4]: 1
+      PROTOCOL_VERSION_MINOR (0x42006b) INTEGER [4]: 1
+    BATCH_COUNT (0x42000d) INTEGER [4]: 1
+  BATCH_ITEM (0x42000f) STRUCTURE [72]
+    OPERATION (0x42005c) ENUMERATION [4]: GET (0x0000000a)
+    REQUEST_PAYLOAD (0x420079) STRUCTURE [48]
+      UNIQUE_IDENTIFIER (0x420094) TEXT_STRING [36]: "49a1ca88-6bea-4fb2-b450-7e58802c3038"
+`, buf.String())
+}
+
+func (s *DumpSuite) TestAllTypes() {
+	var data bytes.Buffer
+
+	s.Require().NoError(NewEncoder(&data).Encode(TTLV{
+		Tag:  COMPROMISE_DATE,
+		Type: STRUCTURE,
+		Children: []TTLV{
+			{Tag: ARCHIVE_DATE, Type: LONG_INTEGER, Value: int64(-5)},
+			{Tag: ARCHIVE_DATE, Type: BIG_INTEGER, Value: big.NewInt(1234567890)},
+			{Tag: CRYPTOGRAPHIC_ALGORITHM, Type: ENUMERATION, Value: CRYPTO_AES},
+			{Tag: ARCHIVE_DATE, Type: ENUMERATION, Value: Enum(255)},
+			{Tag: ARCHIVE_DATE, Type: BOOLEAN, Value: true},
+			{Tag: ARCHIVE_DATE, Type: BYTE_STRING, Value: []byte{1, 2, 3}},
+			{Tag: ARCHIVE_DATE, Type: DATE_TIME, Value: time.Date(2008, 3, 14, 11, 56, 40, 0, time.UTC)},
+			{Tag: ARCHIVE_DATE, Type: INTERVAL, Value: 10 * time.Second},
+			{Tag: 0x540001, Type: INTEGER, Value: int32(1)},
+		},
+	}))
+
+	p := NewPrinter(&bytes.Buffer{})
+	out := p.w.(*bytes.Buffer)
+	p.Prefix = "> "
+	p.Indent = "\t"
+
+	s.Require().NoError(p.Print(data.Bytes()))
+	s.Assert().Equal(`> COMPROMISE_DATE (0x420020) STRUCTURE [144]
+> 	ARCHIVE_DATE (0x420005) LONG_INTEGER [8]: -5
+> 	ARCHIVE_DATE (0x420005) BIG_INTEGER [8]: 1234567890
+> 	CRYPTOGRAPHIC_ALGORITHM (0x420028) ENUMERATION [4]: AES (0x00000003)
+> 	ARCHIVE_DATE (0x420005) ENUMERATION [4]: 0x000000ff
+> 	ARCHIVE_DATE (0x420005) BOOLEAN [8]: true
+> 	ARCHIVE_DATE (0x420005) BYTE_STRING [3]: 010203
+> 	ARCHIVE_DATE (0x420005) DATE_TIME [8]: 2008-03-14T11:56:40Z
+> 	ARCHIVE_DATE (0x420005) INTERVAL [4]: 10s
+> 	0x540001 INTEGER [4]: 1
+`, out.String())
+}
+
+func (s *DumpSuite) TestMalformed() {
+	var buf bytes.Buffer
+
+	// truncated in the middle of the request payload
+	err := Dump(&buf, messageGet[:len(messageGet)-16])
+	s.Assert().EqualError(err, "error reading item at offset 104: padded item length 40 exceeds remaining 24 bytes")
+	s.Assert().Equal(`REQUEST_MESSAGE (0x420078) STRUCTURE [144]
+  REQUEST_HEADER (0x420077) STRUCTURE [56]
+    PROTOCOL_VERSION (0x420069) STRUCTURE [32]
+      PROTOCOL_VERSION_MAJOR (0x42006a) INTEGER [4]: 1
+      PROTOCOL_VERSION_MINOR (0x42006b) INTEGER [4]: 1
+    BATCH_COUNT (0x42000d) INTEGER [4]: 1
+  BATCH_ITEM (0x42000f) STRUCTURE [72]
+    OPERATION (0x42005c) ENUMERATION [4]: GET (0x0000000a)
+    REQUEST_PAYLOAD (0x420079) STRUCTURE [48]
+      UNIQUE_IDENTIFIER (0x420094) TEXT_STRING [36]: 34396131636138382d366265612d346662322d623435302d
+`, buf.String())
+
+	buf.Reset()
+
+	// truncated item header
+	err = Dump(&buf, messageGet[:12])
+	s.Assert().EqualError(err, "error reading item at offset 8: truncated item header")
+	s.Assert().Equal("REQUEST_MESSAGE (0x420078) STRUCTURE [144]\n", buf.String())
+
+	buf.Reset()
+
+	// invalid boolean value
+	err = Dump(&buf, []byte{0x42, 0x00, 0x08, 0x06, 0x00, 0x00, 0x00, 0x08, 0, 0, 0, 0, 0, 0, 0, 2})
+	s.Assert().EqualError(err, "error decoding tag ATTRIBUTE: unexpected boolean value: [0 0 0 0 0 0 0 2]")
+	s.Assert().Equal("ATTRIBUTE (0x420008) BOOLEAN [8]: 0000000000000002\n", buf.String())
+}
+
+func TestDumpSuite(t *testing.T) {
+	suite.Run(t, new(DumpSuite))
+}
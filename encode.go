@@ -5,9 +5,8 @@ package kmip
  * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
 
 import (
-	"bytes"
-	"encoding/binary"
 	"io"
+	"math/big"
 	"reflect"
 	"time"
 
@@ -18,8 +17,9 @@ import (
 //
 // All core types are supported:
 //
-//  * Integer (int32)
+//  * Integer (int32, uint32)
 //  * Long Integer (int64)
+//  * Big Integer (*big.Int)
 //  * Enumeration (Enum)
 //  * Boolean (bool)
 //  * Bytes ([]byte)
@@ -27,6 +27,9 @@ import (
 //  * Timestamp (time.Time)
 //  * Interval (time.Duration)
 //
+// Named types are mapped according to their underlying type
+// (e.g. type Mask uint32 is encoded as Integer).
+//
 // Encoder processes Go structure, analyzing field tags and parsing out
 // `kmip` Go struct tags, e.g.:
 //	  Value string `kmip:"TAG_NAME,required"`
@@ -34,8 +37,22 @@ import (
 // respective KMIP core type (see above), length is automatically calculated.
 //
 // Fields with zero value which are not required are skipped while encoding.
+// Pointer fields (e.g. *bool, *int32, *SomeStruct) are skipped only if
+// nil, so that zero value could be encoded explicitly; while decoding
+// pointer is set only if the field is present.
+//
+// Types implementing Marshaler interface are encoded by calling their
+// MarshalTTLV method, which might use EncodeValue to encode the value
+// as one of the core types (or as a structure).
+//
+// Encoder builds the whole message in a single buffer (structure lengths
+// are backpatched once structure is complete), message is written to
+// the underlying writer with a single Write call.
 type Encoder struct {
 	w io.Writer
+
+	buf   []byte
+	depth int
 }
 
 // NewEncoder builds encoder writing to w
@@ -45,23 +62,55 @@ func NewEncoder(w io.Writer) *Encoder {
 	}
 }
 
-func (e *Encoder) writeTagTypeLength(t Tag, typ Type, l uint32) (err error) {
-	var (
-		b  [8]byte
-		tt [4]byte
-	)
+// Marshal returns TTLV encoding of v
+//
+// See Encoder for details on the encoding.
+func Marshal(v interface{}) ([]byte, error) {
+	return AppendTTLV(nil, v)
+}
 
-	binary.BigEndian.PutUint32(tt[:], uint32(t))
+// AppendTTLV appends TTLV encoding of v to dst and returns the extended buffer
+//
+// If encoding fails, dst is returned unmodified.
+func AppendTTLV(dst []byte, v interface{}) ([]byte, error) {
+	e := &Encoder{buf: dst}
 
-	copy(b[:3], tt[1:])
-	b[3] = byte(typ)
-	binary.BigEndian.PutUint32(b[4:], l)
+	if err := e.Encode(v); err != nil {
+		return dst, err
+	}
 
-	_, err = e.w.Write(b[:])
-	return
+	return e.buf, nil
 }
 
-func (e *Encoder) Encode(v interface{}) (err error) {
+// flush writes encoded message to the underlying writer once top-level call completes
+func (e *Encoder) flush(err error) error {
+	if e.depth > 0 || e.w == nil {
+		return err
+	}
+
+	if err == nil {
+		_, err = e.w.Write(e.buf)
+	}
+
+	e.buf = e.buf[:0]
+
+	return err
+}
+
+// Encode encodes v (Go struct or type implementing Marshaler) as TTLV
+func (e *Encoder) Encode(v interface{}) error {
+	e.depth++
+	err := e.encodeTop(v)
+	e.depth--
+
+	return e.flush(err)
+}
+
+func (e *Encoder) encodeTop(v interface{}) (err error) {
+	if m, ok := v.(Marshaler); ok {
+		return m.MarshalTTLV(e, ANY_TAG)
+	}
+
 	rv := reflect.ValueOf(v)
 	if !rv.IsValid() {
 		return errors.New("invalid value")
@@ -83,51 +132,111 @@ func (e *Encoder) Encode(v interface{}) (err error) {
 	return
 }
 
+// EncodeValue encodes single value v with the tag t
+//
+// EncodeValue is useful to implement Marshaler interface: value
+// is encoded according to its Go type, just like struct fields are.
+func (e *Encoder) EncodeValue(t Tag, v interface{}) error {
+	e.depth++
+	err := e.encodeTopValue(t, v)
+	e.depth--
+
+	return e.flush(err)
+}
+
+func (e *Encoder) encodeTopValue(t Tag, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return errors.New("invalid value")
+	}
+	if rv.Kind() == reflect.Ptr && !isCustomType(rv.Type()) && rv.Type() != typeOfBigInt {
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return errors.New("invalid pointer value")
+	}
+
+	f := field{
+		name: "value",
+		tag:  t,
+	}
+
+	if err := guessType(rv.Type(), &f); err != nil {
+		return err
+	}
+
+	return e.encodeValue(f, rv.Type(), rv)
+}
+
+func marshalerOf(rv reflect.Value) (Marshaler, bool) {
+	if (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface) && rv.IsNil() {
+		return nil, false
+	}
+
+	if rv.Type().Implements(typeOfMarshaler) {
+		return rv.Interface().(Marshaler), true
+	}
+
+	if rv.CanAddr() && reflect.PtrTo(rv.Type()).Implements(typeOfMarshaler) {
+		return rv.Addr().Interface().(Marshaler), true
+	}
+
+	return nil, false
+}
+
 func (e *Encoder) encodeValue(f field, rt reflect.Type, rv reflect.Value) (err error) {
+	if f.ptr {
+		if rv.IsNil() {
+			return errors.Errorf("nil pointer value, field %v", f.name)
+		}
+
+		rv = rv.Elem()
+		rt = rv.Type()
+	}
+
 	if rv.Kind() == reflect.Interface && !rv.IsNil() {
 		rv = rv.Elem()
-		if rv.Kind() == reflect.Ptr {
+		if rv.Kind() == reflect.Ptr && rv.Type() != typeOfBigInt {
 			rv = rv.Elem()
 		}
 		rt = rv.Type()
 
-		switch rt {
-		case typeOfInt32:
-			f.typ = INTEGER
-		case typeOfInt64:
-			f.typ = LONG_INTEGER
-		case typeOfEnum:
-			f.typ = ENUMERATION
-		case typeOfBool:
-			f.typ = BOOLEAN
-		case typeOfBytes:
-			f.typ = BYTE_STRING
-		case typeOfString:
-			f.typ = TEXT_STRING
-		case typeOfTime:
-			f.typ = DATE_TIME
-		case typeOfDuration:
-			f.typ = INTERVAL
+		if err = guessType(rt, &f); err != nil {
+			return errors.WithMessagef(err, "error processing field %v", f.name)
 		}
 	}
 
+	if m, ok := marshalerOf(rv); ok {
+		return m.MarshalTTLV(e, f.tag)
+	}
+
+	if f.custom {
+		return errors.Errorf("type %s doesn't implement Marshaler, field %v", rt.String(), f.name)
+	}
+
 	switch f.typ {
 	case INTEGER:
-		err = e.writeInteger(f.tag, int32(rv.Int()))
+		if rv.Kind() == reflect.Uint32 {
+			e.writeInteger(f.tag, int32(rv.Uint()))
+		} else {
+			e.writeInteger(f.tag, int32(rv.Int()))
+		}
 	case LONG_INTEGER:
-		err = e.writeLongInteger(f.tag, rv.Int())
+		e.writeLongInteger(f.tag, rv.Int())
+	case BIG_INTEGER:
+		e.writeBigInteger(f.tag, rv.Interface().(*big.Int))
 	case ENUMERATION:
-		err = e.writeEnum(f.tag, Enum(rv.Uint()))
+		e.writeEnum(f.tag, Enum(rv.Uint()))
 	case BOOLEAN:
-		err = e.writeBool(f.tag, rv.Bool())
+		e.writeBool(f.tag, rv.Bool())
 	case DATE_TIME:
-		err = e.writeTime(f.tag, rv.Interface().(time.Time))
+		e.writeTime(f.tag, rv.Interface().(time.Time))
 	case INTERVAL:
-		err = e.writeDuration(f.tag, rv.Interface().(time.Duration))
+		e.writeDuration(f.tag, rv.Interface().(time.Duration))
 	case BYTE_STRING:
-		err = e.writeBytes(f.tag, rv.Bytes())
+		e.writeBytes(f.tag, rv.Bytes())
 	case TEXT_STRING:
-		err = e.writeString(f.tag, rv.String())
+		e.writeString(f.tag, rv.String())
 	case STRUCTURE:
 		var structDesc *structDesc
 
@@ -136,9 +245,7 @@ func (e *Encoder) encodeValue(f field, rt reflect.Type, rv reflect.Value) (err e
 			return
 		}
 
-		structDesc.tag = f.tag
-
-		err = e.encode(rv, structDesc)
+		err = e.encode(rv, structDesc.withTag(f.tag))
 	default:
 		err = errors.Errorf("unsupported type for encode, field %v", f.name)
 	}
@@ -163,6 +270,10 @@ func isZeroValue(rv reflect.Value) (bool, error) {
 			return rv.Interface().(time.Time).IsZero(), nil
 		}
 
+		if isCustomType(rv.Type()) {
+			return rv.IsZero(), nil
+		}
+
 		sD, err := getStructDesc(rv.Type())
 		if err != nil {
 			return false, err
@@ -180,15 +291,16 @@ func isZeroValue(rv reflect.Value) (bool, error) {
 
 		return true, nil
 	default:
+		if isCustomType(rv.Type()) {
+			return rv.IsZero(), nil
+		}
+
 		return false, errors.Errorf("unsupported value for isZeroValue: %v", rv.Kind().String())
 	}
 }
 
 func (e *Encoder) encode(rv reflect.Value, sd *structDesc) (err error) {
-	// build new encoder to encode into temp buf (to know the length)
-	var buf bytes.Buffer
-
-	ee := NewEncoder(&buf)
+	offset := e.beginStructure(sd.tag)
 
 	for _, f := range sd.fields {
 		if f.tag == ANY_TAG || f.skip {
@@ -199,7 +311,7 @@ func (e *Encoder) encode(rv reflect.Value, sd *structDesc) (err error) {
 
 		if f.sliceof {
 			for i := 0; i < ff.Len(); i++ {
-				err = ee.encodeValue(f, ff.Type().Elem(), ff.Index(i))
+				err = e.encodeValue(f, ff.Type().Elem(), ff.Index(i))
 				if err != nil {
 					return
 				}
@@ -217,18 +329,14 @@ func (e *Encoder) encode(rv reflect.Value, sd *structDesc) (err error) {
 				}
 			}
 
-			err = ee.encodeValue(f, ff.Type(), ff)
+			err = e.encodeValue(f, ff.Type(), ff)
 			if err != nil {
 				return
 			}
 		}
 	}
 
-	err = e.writeTagTypeLength(sd.tag, STRUCTURE, uint32(buf.Len()))
-	if err != nil {
-		return
-	}
+	e.endStructure(offset)
 
-	_, err = io.Copy(e.w, &buf)
 	return
 }
@@ -6,119 +6,131 @@ package kmip
 
 import (
 	"encoding/binary"
+	"math/big"
 	"time"
 )
 
-func (e *Encoder) writeInteger(t Tag, v int32) (err error) {
-	err = e.writeTagTypeLength(t, INTEGER, 4)
-	if err != nil {
-		return
-	}
-
-	var b [8]byte
+var zeroPad [8]byte
 
-	binary.BigEndian.PutUint32(b[:4], uint32(v))
-
-	_, err = e.w.Write(b[:])
-	return
+func (e *Encoder) writeTagTypeLength(t Tag, typ Type, l uint32) {
+	e.buf = append(e.buf, byte(t>>16), byte(t>>8), byte(t), byte(typ), 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(e.buf[len(e.buf)-4:], l)
 }
 
-func (e *Encoder) writeLongInteger(t Tag, v int64) (err error) {
-	err = e.writeTagTypeLength(t, LONG_INTEGER, 8)
-	if err != nil {
-		return
-	}
-
-	var b [8]byte
-
-	binary.BigEndian.PutUint64(b[:], uint64(v))
+// beginStructure writes structure header with placeholder length
+//
+// Returned offset should be passed to endStructure once all the fields are written.
+func (e *Encoder) beginStructure(t Tag) int {
+	e.writeTagTypeLength(t, STRUCTURE, 0)
 
-	_, err = e.w.Write(b[:])
-	return
+	return len(e.buf)
 }
 
-func (e *Encoder) writeEnum(t Tag, v Enum) (err error) {
-	err = e.writeTagTypeLength(t, ENUMERATION, 4)
-	if err != nil {
-		return
-	}
-
-	var b [8]byte
+// endStructure backpatches structure length
+func (e *Encoder) endStructure(offset int) {
+	binary.BigEndian.PutUint32(e.buf[offset-4:offset], uint32(len(e.buf)-offset))
+}
 
-	binary.BigEndian.PutUint32(b[:4], uint32(v))
+func (e *Encoder) writeUint32(v uint32) {
+	e.buf = append(e.buf, 0, 0, 0, 0, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(e.buf[len(e.buf)-8:], v)
+}
 
-	_, err = e.w.Write(b[:])
-	return
+func (e *Encoder) writeUint64(v uint64) {
+	e.buf = append(e.buf, 0, 0, 0, 0, 0, 0, 0, 0)
+	binary.BigEndian.PutUint64(e.buf[len(e.buf)-8:], v)
 }
 
-func (e *Encoder) writeBool(t Tag, v bool) (err error) {
-	err = e.writeTagTypeLength(t, BOOLEAN, 8)
-	if err != nil {
-		return
-	}
+func (e *Encoder) writeInteger(t Tag, v int32) {
+	e.writeTagTypeLength(t, INTEGER, 4)
+	e.writeUint32(uint32(v))
+}
 
-	var b [8]byte
+func (e *Encoder) writeLongInteger(t Tag, v int64) {
+	e.writeTagTypeLength(t, LONG_INTEGER, 8)
+	e.writeUint64(uint64(v))
+}
 
-	if v {
-		b[7] = 1
+// bigIntegerLength returns length of the Big Integer v encoding
+func bigIntegerLength(v *big.Int) int {
+	// two's complement, sign-extended to the multiple of 8 bytes
+	l := v.BitLen()/8 + 1
+	if l%8 != 0 {
+		l += 8 - l%8
 	}
 
-	_, err = e.w.Write(b[:])
-	return
+	return l
 }
 
-func (e *Encoder) writeByteSlice(t Tag, typ Type, b []byte) (err error) {
-	err = e.writeTagTypeLength(t, typ, uint32(len(b)))
-	if err != nil {
-		return
-	}
+func (e *Encoder) writeBigInteger(t Tag, v *big.Int) {
+	l := bigIntegerLength(v)
 
-	_, err = e.w.Write(b)
-	if err != nil {
-		return
-	}
+	e.writeTagTypeLength(t, BIG_INTEGER, uint32(l))
 
-	if len(b)%8 != 0 {
-		var pad [8]byte
+	offset := len(e.buf)
+	e.buf = append(e.buf, make([]byte, l)...)
+	b := e.buf[offset:]
 
-		_, err = e.w.Write(pad[:8-len(b)%8])
-	}
+	if v.Sign() < 0 {
+		var c big.Int
 
-	return
+		c.Lsh(big.NewInt(1), uint(l*8))
+		c.Add(&c, v)
+		c.FillBytes(b)
+	} else {
+		v.FillBytes(b)
+	}
 }
 
-func (e *Encoder) writeBytes(t Tag, b []byte) error {
-	return e.writeByteSlice(t, BYTE_STRING, b)
+// writeBigIntegerRaw writes Big Integer with already encoded value b
+func (e *Encoder) writeBigIntegerRaw(t Tag, b []byte) {
+	e.writeTagTypeLength(t, BIG_INTEGER, uint32(len(b)))
+	e.buf = append(e.buf, b...)
 }
 
-func (e *Encoder) writeString(t Tag, s string) error {
-	return e.writeByteSlice(t, TEXT_STRING, []byte(s))
+func (e *Encoder) writeEnum(t Tag, v Enum) {
+	e.writeTagTypeLength(t, ENUMERATION, 4)
+	e.writeUint32(uint32(v))
 }
 
-func (e *Encoder) writeTime(t Tag, v time.Time) (err error) {
-	err = e.writeTagTypeLength(t, DATE_TIME, 8)
-	if err != nil {
-		return
+func (e *Encoder) writeBool(t Tag, v bool) {
+	e.writeTagTypeLength(t, BOOLEAN, 8)
+
+	if v {
+		e.writeUint64(1)
+	} else {
+		e.writeUint64(0)
 	}
+}
 
-	var b [8]byte
+func (e *Encoder) writeByteSlice(t Tag, typ Type, b []byte) {
+	e.writeTagTypeLength(t, typ, uint32(len(b)))
+	e.buf = append(e.buf, b...)
 
-	binary.BigEndian.PutUint64(b[:], uint64(v.Unix()))
+	if len(b)%8 != 0 {
+		e.buf = append(e.buf, zeroPad[:8-len(b)%8]...)
+	}
+}
 
-	_, err = e.w.Write(b[:])
-	return
+func (e *Encoder) writeBytes(t Tag, b []byte) {
+	e.writeByteSlice(t, BYTE_STRING, b)
 }
 
-func (e *Encoder) writeDuration(t Tag, v time.Duration) (err error) {
-	err = e.writeTagTypeLength(t, INTERVAL, 4)
-	if err != nil {
-		return
-	}
+func (e *Encoder) writeString(t Tag, s string) {
+	e.writeTagTypeLength(t, TEXT_STRING, uint32(len(s)))
+	e.buf = append(e.buf, s...)
 
-	var b [8]byte
+	if len(s)%8 != 0 {
+		e.buf = append(e.buf, zeroPad[:8-len(s)%8]...)
+	}
+}
 
-	binary.BigEndian.PutUint32(b[:4], uint32(v/time.Second))
+func (e *Encoder) writeTime(t Tag, v time.Time) {
+	e.writeTagTypeLength(t, DATE_TIME, 8)
+	e.writeUint64(uint64(v.Unix()))
+}
 
-	_, err = e.w.Write(b[:])
-	return
+func (e *Encoder) writeDuration(t Tag, v time.Duration) {
+	e.writeTagTypeLength(t, INTERVAL, 4)
+	e.writeUint32(uint32(v / time.Second))
 }
@@ -7,6 +7,8 @@ package kmip
 import (
 	"bytes"
 	"encoding/hex"
+	"math/big"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -14,6 +16,25 @@ import (
 	"github.com/stretchr/testify/suite"
 )
 
+// testMask is a custom type encoded as KMIP Integer
+type testMask uint32
+
+func (m testMask) MarshalTTLV(e *Encoder, tag Tag) error {
+	return e.EncodeValue(tag, int32(m))
+}
+
+func (m *testMask) UnmarshalTTLV(d *Decoder, tag Tag) error {
+	var v int32
+
+	if err := d.DecodeValue(tag, &v); err != nil {
+		return err
+	}
+
+	*m = testMask(v)
+
+	return nil
+}
+
 type EncoderSuite struct {
 	suite.Suite
 }
@@ -28,77 +49,97 @@ func (s *EncoderSuite) parseSpecValue(val string) []byte {
 }
 
 func (s *EncoderSuite) TestWriteInteger() {
-	var buf bytes.Buffer
+	var e Encoder
 
-	err := NewEncoder(&buf).writeInteger(COMPROMISE_DATE, 8)
-	s.Assert().NoError(err)
+	e.writeInteger(COMPROMISE_DATE, 8)
 
-	s.Assert().EqualValues(s.parseSpecValue("42 00 20 | 02 | 00 00 00 04 | 00 00 00 08 00 00 00 00"), buf.Bytes())
+	s.Assert().EqualValues(s.parseSpecValue("42 00 20 | 02 | 00 00 00 04 | 00 00 00 08 00 00 00 00"), e.buf)
 }
 
 func (s *EncoderSuite) TestWriteLongInteger() {
-	var buf bytes.Buffer
+	var e Encoder
 
-	err := NewEncoder(&buf).writeLongInteger(COMPROMISE_DATE, 123456789000000000)
-	s.Assert().NoError(err)
+	e.writeLongInteger(COMPROMISE_DATE, 123456789000000000)
 
-	s.Assert().EqualValues(s.parseSpecValue("42 00 20 | 03 | 00 00 00 08 | 01 B6 9B 4B A5 74 92 00"), buf.Bytes())
+	s.Assert().EqualValues(s.parseSpecValue("42 00 20 | 03 | 00 00 00 08 | 01 B6 9B 4B A5 74 92 00"), e.buf)
+}
+
+func (s *EncoderSuite) TestWriteBigInteger() {
+	var e Encoder
+
+	v, _ := new(big.Int).SetString("1234567890000000000000000000", 10)
+
+	e.writeBigInteger(COMPROMISE_DATE, v)
+
+	s.Assert().EqualValues(s.parseSpecValue("42 00 20 | 04 | 00 00 00 10 | 00 00 00 00 03 FD 35 EB 6B C2 DF 46 18 08 00 00"), e.buf)
+
+	e.buf = nil
+
+	e.writeBigInteger(COMPROMISE_DATE, big.NewInt(-1))
+
+	s.Assert().EqualValues(s.parseSpecValue("42 00 20 | 04 | 00 00 00 08 | FF FF FF FF FF FF FF FF"), e.buf)
+
+	e.buf = nil
+
+	e.writeBigInteger(COMPROMISE_DATE, big.NewInt(0))
+
+	s.Assert().EqualValues(s.parseSpecValue("42 00 20 | 04 | 00 00 00 08 | 00 00 00 00 00 00 00 00"), e.buf)
+
+	e.buf = nil
+
+	e.writeBigInteger(COMPROMISE_DATE, big.NewInt(-129))
+
+	s.Assert().EqualValues(s.parseSpecValue("42 00 20 | 04 | 00 00 00 08 | FF FF FF FF FF FF FF 7F"), e.buf)
 }
 
 func (s *EncoderSuite) TestWriteEnum() {
-	var buf bytes.Buffer
+	var e Encoder
 
-	err := NewEncoder(&buf).writeEnum(COMPROMISE_DATE, Enum(255))
-	s.Assert().NoError(err)
+	e.writeEnum(COMPROMISE_DATE, Enum(255))
 
-	s.Assert().EqualValues(s.parseSpecValue("42 00 20 | 05 | 00 00 00 04 | 00 00 00 FF 00 00 00 00"), buf.Bytes())
+	s.Assert().EqualValues(s.parseSpecValue("42 00 20 | 05 | 00 00 00 04 | 00 00 00 FF 00 00 00 00"), e.buf)
 }
 
 func (s *EncoderSuite) TestWriteBool() {
-	var buf bytes.Buffer
+	var e Encoder
 
-	err := NewEncoder(&buf).writeBool(COMPROMISE_DATE, true)
-	s.Assert().NoError(err)
+	e.writeBool(COMPROMISE_DATE, true)
 
-	s.Assert().EqualValues(s.parseSpecValue("42 00 20 | 06 | 00 00 00 08 | 00 00 00 00 00 00 00 01"), buf.Bytes())
+	s.Assert().EqualValues(s.parseSpecValue("42 00 20 | 06 | 00 00 00 08 | 00 00 00 00 00 00 00 01"), e.buf)
 }
 
 func (s *EncoderSuite) TestWriteBytes() {
-	var buf bytes.Buffer
+	var e Encoder
 
-	err := NewEncoder(&buf).writeBytes(COMPROMISE_DATE, []byte{1, 2, 3})
-	s.Assert().NoError(err)
+	e.writeBytes(COMPROMISE_DATE, []byte{1, 2, 3})
 
-	s.Assert().EqualValues(s.parseSpecValue("42 00 20 | 08 | 00 00 00 03 | 01 02 03 00 00 00 00 00"), buf.Bytes())
+	s.Assert().EqualValues(s.parseSpecValue("42 00 20 | 08 | 00 00 00 03 | 01 02 03 00 00 00 00 00"), e.buf)
 }
 
 func (s *EncoderSuite) TestWriteString() {
-	var buf bytes.Buffer
+	var e Encoder
 
-	err := NewEncoder(&buf).writeString(COMPROMISE_DATE, "Hello World")
-	s.Assert().NoError(err)
+	e.writeString(COMPROMISE_DATE, "Hello World")
 
-	s.Assert().EqualValues(s.parseSpecValue("42 00 20 | 07 | 00 00 00 0B | 48 65 6C 6C 6F 20 57 6F 72 6C 64 00 00 00 00 00"), buf.Bytes())
+	s.Assert().EqualValues(s.parseSpecValue("42 00 20 | 07 | 00 00 00 0B | 48 65 6C 6C 6F 20 57 6F 72 6C 64 00 00 00 00 00"), e.buf)
 }
 
 func (s *EncoderSuite) TestWriteTime() {
-	var buf bytes.Buffer
+	var e Encoder
 
 	t, _ := time.Parse(time.RFC3339, "2008-03-14T11:56:40Z")
 
-	err := NewEncoder(&buf).writeTime(COMPROMISE_DATE, t)
-	s.Assert().NoError(err)
+	e.writeTime(COMPROMISE_DATE, t)
 
-	s.Assert().EqualValues(s.parseSpecValue("42 00 20 | 09 | 00 00 00 08 | 00 00 00 00 47 DA 67 F8"), buf.Bytes())
+	s.Assert().EqualValues(s.parseSpecValue("42 00 20 | 09 | 00 00 00 08 | 00 00 00 00 47 DA 67 F8"), e.buf)
 }
 
 func (s *EncoderSuite) TestWriteInterval() {
-	var buf bytes.Buffer
+	var e Encoder
 
-	err := NewEncoder(&buf).writeDuration(COMPROMISE_DATE, 10*24*time.Hour)
-	s.Assert().NoError(err)
+	e.writeDuration(COMPROMISE_DATE, 10*24*time.Hour)
 
-	s.Assert().EqualValues(s.parseSpecValue("42 00 20 | 0A | 00 00 00 04 | 00 0D 2F 00 00 00 00 00"), buf.Bytes())
+	s.Assert().EqualValues(s.parseSpecValue("42 00 20 | 0A | 00 00 00 04 | 00 0D 2F 00 00 00 00 00"), e.buf)
 }
 
 func (s *EncoderSuite) TestEncodeStruct() {
@@ -122,6 +163,57 @@ func (s *EncoderSuite) TestEncodeStruct() {
 		" 42 00 05 | 02 | 00 00 00 04 | 00 00 00 FF 00 00 00 00"), buf.Bytes())
 }
 
+// testOptional covers pointer and named type fields
+type testOptional struct {
+	Tag `kmip:"COMPROMISE_DATE"`
+
+	Length  *int32           `kmip:"CRYPTOGRAPHIC_LENGTH"`
+	Init    *bool            `kmip:"INIT_INDICATOR"`
+	Mask    testFlags        `kmip:"CRYPTOGRAPHIC_USAGE_MASK"`
+	Name    testName         `kmip:"NAME_VALUE"`
+	Version *ProtocolVersion `kmip:"PROTOCOL_VERSION"`
+	Fresh   []*bool          `kmip:"FRESH"`
+}
+
+type (
+	testFlags uint32
+	testName  string
+)
+
+func (s *EncoderSuite) TestEncodeStructOptional() {
+	var (
+		length int32
+		init   bool
+		fresh  = true
+	)
+
+	data, err := Marshal(&testOptional{
+		Length:  &length,
+		Init:    &init,
+		Mask:    0x80000001,
+		Name:    "key",
+		Version: &ProtocolVersion{},
+		Fresh:   []*bool{&fresh, &init},
+	})
+	s.Require().NoError(err)
+	s.Assert().EqualValues(s.parseSpecValue("42 00 20 | 01 | 00 00 00 68 |"+
+		" 42 00 2A | 02 | 00 00 00 04 | 00 00 00 00 00 00 00 00 |"+
+		" 42 00 D7 | 06 | 00 00 00 08 | 00 00 00 00 00 00 00 00 |"+
+		" 42 00 2C | 02 | 00 00 00 04 | 80 00 00 01 00 00 00 00 |"+
+		" 42 00 55 | 07 | 00 00 00 03 | 6B 65 79 00 00 00 00 00 |"+
+		" 42 00 69 | 01 | 00 00 00 00 |"+
+		" 42 00 A8 | 06 | 00 00 00 08 | 00 00 00 00 00 00 00 01 |"+
+		" 42 00 A8 | 06 | 00 00 00 08 | 00 00 00 00 00 00 00 00"), data)
+
+	// nil pointers are skipped
+	data, err = Marshal(&testOptional{})
+	s.Require().NoError(err)
+	s.Assert().EqualValues(s.parseSpecValue("42 00 20 | 01 | 00 00 00 00"), data)
+
+	_, err = Marshal(&testOptional{Fresh: []*bool{nil}})
+	s.Assert().EqualError(err, "nil pointer value, field Fresh")
+}
+
 func (s *EncoderSuite) TestEncodeStructWithTimeInterval() {
 	var buf bytes.Buffer
 
@@ -141,6 +233,49 @@ func (s *EncoderSuite) TestEncodeStructWithTimeInterval() {
 		" 42 00 01 | 0A | 00 00 00 04 |  00 0D 2F 00 00 00 00 00"), buf.Bytes())
 }
 
+func (s *EncoderSuite) TestEncodeStructWithBigInteger() {
+	var buf bytes.Buffer
+
+	type tt struct {
+		Tag `kmip:"COMPROMISE_DATE"`
+		A   *big.Int `kmip:"MODULUS"`
+		B   *big.Int `kmip:"PRIVATE_EXPONENT"`
+	}
+
+	var v = tt{A: big.NewInt(0x10001)}
+
+	err := NewEncoder(&buf).Encode(&v)
+	s.Assert().NoError(err)
+
+	s.Assert().EqualValues(s.parseSpecValue("42 00 20 | 01 | 00 00 00 10 | 42 00 52 | 04 | 00 00 00 08 | 00 00 00 00 00 01 00 01"), buf.Bytes())
+}
+
+func (s *EncoderSuite) TestEncodeStructCustom() {
+	var buf bytes.Buffer
+
+	type tt struct {
+		Tag `kmip:"COMPROMISE_DATE"`
+		A   testMask   `kmip:"CRYPTOGRAPHIC_USAGE_MASK"`
+		B   []testMask `kmip:"ARCHIVE_DATE"`
+		C   testMask   `kmip:"ACTIVATION_DATE"`
+	}
+
+	var v = tt{A: 12, B: []testMask{254, 255}}
+
+	err := NewEncoder(&buf).Encode(&v)
+	s.Assert().NoError(err)
+
+	s.Assert().EqualValues(s.parseSpecValue("42 00 20 | 01 | 00 00 00 30 | 42 00 2C | 02 | 00 00 00 04 | 00 00 00 0C 00 00 00 00 |"+
+		" 42 00 05 | 02 | 00 00 00 04 | 00 00 00 FE 00 00 00 00 | 42 00 05 | 02 | 00 00 00 04 | 00 00 00 FF 00 00 00 00"), buf.Bytes())
+
+	buf.Reset()
+
+	err = NewEncoder(&buf).EncodeValue(COMPROMISE_DATE, testMask(8))
+	s.Assert().NoError(err)
+
+	s.Assert().EqualValues(s.parseSpecValue("42 00 20 | 02 | 00 00 00 04 | 00 00 00 08 00 00 00 00"), buf.Bytes())
+}
+
 func (s *EncoderSuite) TestEncodeMessageCreate() {
 	var buf bytes.Buffer
 
@@ -233,6 +368,123 @@ func (s *EncoderSuite) TestEncodeMessageGetPointer() {
 	s.Assert().EqualValues(messageGet, buf.Bytes())
 }
 
+func (s *EncoderSuite) TestEncodeSharedStructDesc() {
+	type tt struct {
+		Tag `kmip:"COMPROMISE_DATE"`
+
+		A ProtocolVersion `kmip:"ARCHIVE_DATE"`
+		B ProtocolVersion `kmip:"PROTOCOL_VERSION"`
+	}
+
+	var buf bytes.Buffer
+
+	for i := 0; i < 2; i++ {
+		buf.Reset()
+
+		s.Require().NoError(NewEncoder(&buf).Encode(&tt{A: ProtocolVersion{Major: 1, Minor: 1}, B: ProtocolVersion{Major: 1, Minor: 2}}))
+		s.Assert().EqualValues(s.parseSpecValue("4200200100000050"+
+			"420005010000002042006a0200000004000000010000000042006b02000000040000000100000000"+
+			"420069010000002042006a0200000004000000010000000042006b02000000040000000200000000"), buf.Bytes())
+	}
+
+	sD, err := getStructDesc(reflect.TypeOf(ProtocolVersion{}))
+	s.Require().NoError(err)
+	s.Assert().Equal(PROTOCOL_VERSION, sD.tag)
+
+	sD2, err := getStructDesc(reflect.TypeOf(ProtocolVersion{}))
+	s.Require().NoError(err)
+	s.Assert().Same(sD, sD2)
+}
+
+func (s *EncoderSuite) TestEncodeRevocationReason() {
+	expected := s.parseSpecValue("42 00 81 | 01 | 00 00 00 20 | " +
+		"42 00 82 | 05 | 00 00 00 04 | 00 00 00 02 00 00 00 00 | " +
+		"42 00 80 | 07 | 00 00 00 06 | 6C 65 61 6B 65 64 00 00")
+
+	data, err := Marshal(RevocationReason{RevocationReasonCode: REVOCATION_REASON_KEY_COMPROMISE, RevocationMessage: "leaked"})
+	s.Require().NoError(err)
+	s.Assert().EqualValues(expected, data)
+
+	var r RevocationReason
+	s.Require().NoError(NewDecoder(bytes.NewReader(expected)).Decode(&r))
+	s.Assert().Equal(RevocationReason{RevocationReasonCode: REVOCATION_REASON_KEY_COMPROMISE, RevocationMessage: "leaked"}, r)
+}
+
+func (s *EncoderSuite) TestMarshal() {
+	data, err := Marshal(TTLV{Tag: COMPROMISE_DATE, Type: INTEGER, Value: int32(8)})
+	s.Require().NoError(err)
+	s.Assert().EqualValues(s.parseSpecValue("42 00 20 | 02 | 00 00 00 04 | 00 00 00 08 00 00 00 00"), data)
+
+	dst := []byte{0xff}
+
+	dst, err = AppendTTLV(dst, messageGetRequest())
+	s.Require().NoError(err)
+	s.Assert().EqualValues(append([]byte{0xff}, messageGet...), dst)
+
+	// failed encoding leaves dst untouched
+	dst2, err := AppendTTLV(dst, TTLV{Tag: COMPROMISE_DATE, Type: STRUCTURE, Children: []TTLV{
+		{Tag: ARCHIVE_DATE, Type: INTEGER, Value: int32(1)},
+		{Tag: ARCHIVE_DATE, Type: INTEGER, Value: "foo"},
+	}})
+	s.Assert().EqualError(err, "unexpected value string for type 2, tag 420005")
+	s.Assert().Equal(dst, dst2)
+}
+
+func (s *EncoderSuite) TestEncodeErrorNoPartialWrite() {
+	var buf bytes.Buffer
+
+	e := NewEncoder(&buf)
+
+	err := e.Encode(TTLV{Tag: COMPROMISE_DATE, Type: STRUCTURE, Children: []TTLV{
+		{Tag: ARCHIVE_DATE, Type: INTEGER, Value: int32(1)},
+		{Tag: ARCHIVE_DATE, Type: INTEGER, Value: "foo"},
+	}})
+	s.Assert().Error(err)
+	s.Assert().Equal(0, buf.Len())
+
+	// encoder is still usable after an error
+	s.Require().NoError(e.Encode(messageGetRequest()))
+	s.Assert().EqualValues(messageGet, buf.Bytes())
+}
+
+func messageGetRequest() *Request {
+	return &Request{
+		Header: RequestHeader{
+			Version:    ProtocolVersion{Major: 1, Minor: 1},
+			BatchCount: 1,
+		},
+		BatchItems: []RequestBatchItem{
+			{
+				Operation: OPERATION_GET,
+				RequestPayload: GetRequest{
+					UniqueIdentifier: "49a1ca88-6bea-4fb2-b450-7e58802c3038",
+				},
+			},
+		},
+	}
+}
+
 func TestEncoderSuite(t *testing.T) {
 	suite.Run(t, new(EncoderSuite))
 }
+
+func BenchmarkEncodeMessageCreate(b *testing.B) {
+	var m Request
+
+	if err := NewDecoder(bytes.NewReader(messageCreate)).Decode(&m); err != nil {
+		b.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+
+		if err := NewEncoder(&buf).Encode(&m); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -4,6 +4,13 @@ package kmip
  * License, v. 2.0. If a copy of the MPL was not distributed with this
  * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
 
+import (
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
 // Error enhances error with "Result Reason" field
 //
 // Any Error instance is returned back to the caller with message and
@@ -25,3 +32,83 @@ func (e protocolError) ResultReason() Enum {
 func wrapError(err error, reason Enum) protocolError {
 	return protocolError{err, reason}
 }
+
+// DecodeError describes failure to decode TTLV message
+//
+// DecodeError implements Error, so it can be reported back to the client:
+// malformed (e.g. truncated) messages result in RESULT_REASON_INVALID_MESSAGE,
+// unexpected tags, types and values result in RESULT_REASON_INVALID_FIELD.
+// Failures to read from the underlying reader (e.g. read timeout) are not
+// DecodeError, as they don't indicate problem with the message.
+type DecodeError struct {
+	// Offset of the item which failed to be decoded from the beginning of the message
+	Offset int64
+	// Path is a tag path to the item, e.g. RequestMessage/BatchItem[2]/RequestPayload/Attribute[1]
+	Path string
+
+	// ExpectedTag and Tag are set if tag of the item doesn't match expected tag
+	ExpectedTag, Tag Tag
+	// ExpectedType and Type are set if type of the item doesn't match expected type
+	ExpectedType, Type Type
+
+	// Err is the reason of the failure
+	Err error
+
+	reason Enum
+}
+
+func (e *DecodeError) Error() string {
+	if e.Path == "" {
+		return e.Err.Error()
+	}
+
+	return fmt.Sprintf("error decoding %s at offset %d: %s", e.Path, e.Offset, e.Err)
+}
+
+// Cause returns the reason of the failure
+func (e *DecodeError) Cause() error {
+	return e.Err
+}
+
+// Unwrap returns the reason of the failure
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// ResultReason implements Error interface
+func (e *DecodeError) ResultReason() Enum {
+	if e.reason != 0 {
+		return e.reason
+	}
+
+	cause := errors.Cause(e.Err)
+
+	if protoErr, ok := cause.(Error); ok {
+		return protoErr.ResultReason()
+	}
+
+	if cause == io.EOF || cause == io.ErrUnexpectedEOF {
+		return RESULT_REASON_INVALID_MESSAGE
+	}
+
+	return RESULT_REASON_INVALID_FIELD
+}
+
+// wrapDecodeError adds path segment to the decoding error
+//
+// Offset is recorded by the innermost call, as it points to the failing item.
+func wrapDecodeError(err error, segment string, offset int64) error {
+	de, ok := err.(*DecodeError)
+	if !ok {
+		de = &DecodeError{Err: err}
+	}
+
+	if de.Path == "" {
+		de.Path = segment
+		de.Offset = offset
+	} else {
+		de.Path = segment + "/" + de.Path
+	}
+
+	return de
+}
@@ -7,6 +7,7 @@ package kmip
 import (
 	"reflect"
 	"strings"
+	"sync"
 
 	"github.com/pkg/errors"
 )
@@ -18,8 +19,10 @@ type field struct {
 	typ      Type
 	required bool
 	sliceof  bool
+	ptr      bool
 	skip     bool
 	dynamic  bool
+	custom   bool
 }
 
 type structDesc struct {
@@ -28,6 +31,23 @@ type structDesc struct {
 	fields []field
 }
 
+// withTag returns copy of the descriptor with tag overridden
+//
+// Descriptors returned by getStructDesc are shared, so they should never be modified in place.
+func (sD *structDesc) withTag(tag Tag) *structDesc {
+	if sD.tag == tag {
+		return sD
+	}
+
+	res := *sD
+	res.tag = tag
+
+	return &res
+}
+
+// structDescCache caches parsed struct descriptors: reflect.Type -> *structDesc
+var structDescCache sync.Map
+
 func parseTag(tag string) (name, opt string) {
 	parts := strings.SplitN(tag, ",", 2)
 	name = parts[0]
@@ -38,12 +58,25 @@ func parseTag(tag string) (name, opt string) {
 	return
 }
 
+func isCustomType(ft reflect.Type) bool {
+	return ft.Implements(typeOfMarshaler) || ft.Implements(typeOfUnmarshaler) ||
+		reflect.PtrTo(ft).Implements(typeOfMarshaler) ||
+		reflect.PtrTo(ft).Implements(typeOfUnmarshaler)
+}
+
 func guessType(ft reflect.Type, f *field) error {
+	if isCustomType(ft) {
+		f.custom = true
+		return nil
+	}
+
 	switch ft {
 	case typeOfInt32:
 		f.typ = INTEGER
 	case typeOfInt64:
 		f.typ = LONG_INTEGER
+	case typeOfBigInt:
+		f.typ = BIG_INTEGER
 	case typeOfEnum:
 		f.typ = ENUMERATION
 	case typeOfBool:
@@ -57,12 +90,28 @@ func guessType(ft reflect.Type, f *field) error {
 	case typeOfDuration:
 		f.typ = INTERVAL
 	default:
-		if ft.Kind() == reflect.Struct {
+		// named types are mapped by their underlying kind
+		switch ft.Kind() {
+		case reflect.Int32, reflect.Uint32:
+			f.typ = INTEGER
+		case reflect.Int64:
+			f.typ = LONG_INTEGER
+		case reflect.Bool:
+			f.typ = BOOLEAN
+		case reflect.String:
+			f.typ = TEXT_STRING
+		case reflect.Slice:
+			if ft.Elem().Kind() != reflect.Uint8 {
+				return errors.Errorf("unsupported type %s", ft.String())
+			}
+
+			f.typ = BYTE_STRING
+		case reflect.Struct:
 			f.typ = STRUCTURE
-		} else if ft.Kind() == reflect.Interface {
+		case reflect.Interface:
 			f.typ = STRUCTURE
 			f.dynamic = true
-		} else {
+		default:
 			return errors.Errorf("unsupported type %s", ft.String())
 		}
 	}
@@ -70,7 +119,25 @@ func guessType(ft reflect.Type, f *field) error {
 	return nil
 }
 
+// getStructDesc returns (cached) descriptor for the struct type
+//
+// Returned descriptor is shared and should not be modified.
 func getStructDesc(tt reflect.Type) (*structDesc, error) {
+	if sD, ok := structDescCache.Load(tt); ok {
+		return sD.(*structDesc), nil
+	}
+
+	res, err := buildStructDesc(tt)
+	if err != nil {
+		return nil, err
+	}
+
+	sD, _ := structDescCache.LoadOrStore(tt, res)
+
+	return sD.(*structDesc), nil
+}
+
+func buildStructDesc(tt reflect.Type) (*structDesc, error) {
 	res := &structDesc{}
 
 	for i := 0; i < tt.NumField(); i++ {
@@ -104,11 +171,17 @@ func getStructDesc(tt reflect.Type) (*structDesc, error) {
 		f.skip = strings.Contains(opt, "skip")
 
 		ft := ff.Type
-		if ft.Kind() == reflect.Slice && ft != typeOfBytes {
+		if ft.Kind() == reflect.Slice && ft.Elem().Kind() != reflect.Uint8 {
 			f.sliceof = true
 			ft = ft.Elem()
 		}
 
+		// pointers mark optional fields: nil pointer is skipped, zero value is encoded
+		if ft.Kind() == reflect.Ptr && ft != typeOfBigInt && !isCustomType(ft) {
+			f.ptr = true
+			ft = ft.Elem()
+		}
+
 		if err := guessType(ft, &f); err != nil {
 			return nil, errors.WithMessagef(err, "error processing field %v", ff.Name)
 		}
@@ -0,0 +1,196 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+// gen.go generates consts_string.go with reverse lookup tables for
+// tags and enumerations declared in consts.go
+//
+// Run with `go generate`.
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// enumPrefixes maps enumeration constant prefix to the tags enumeration is used with
+//
+// Order matters: longer prefixes should go first.
+var enumPrefixes = []struct {
+	prefix string
+	tags   []string
+}{
+	{"OPERATION_", []string{"OPERATION"}},
+	{"OBJECT_TYPE_", []string{"OBJECT_TYPE"}},
+	{"STATE_", []string{"STATE"}},
+	{"KEY_FORMAT_", []string{"KEY_FORMAT_TYPE"}},
+	{"KEY_WRAP_", []string{"KEY_WRAP_TYPE"}},
+	{"WRAPPING_METHOD_", []string{"WRAPPING_METHOD"}},
+	{"KEY_COMPRESSION_", []string{"KEY_COMPRESSION_TYPE"}},
+	{"NAME_TYPE_", []string{"NAME_TYPE"}},
+	{"CRYPTO_", []string{"CRYPTOGRAPHIC_ALGORITHM"}},
+	{"PADDING_METHOD_", []string{"PADDING_METHOD"}},
+	{"HASH_", []string{"HASHING_ALGORITHM", "MASK_GENERATOR_HASHING_ALGORITHM"}},
+	{"CREDENTIAL_TYPE_", []string{"CREDENTIAL_TYPE"}},
+	{"RESULT_STATUS_", []string{"RESULT_STATUS"}},
+	{"RESULT_REASON_", []string{"RESULT_REASON"}},
+	{"REVOCATION_REASON_", []string{"REVOCATION_REASON_CODE"}},
+	{"BLOCK_MODE_", []string{"BLOCK_CIPHER_MODE"}},
+	{"LINK_TYPE_", []string{"LINK_TYPE"}},
+	{"USAGE_LIMITS_UNIT_", []string{"USAGE_LIMITS_UNIT"}},
+	{"CERTIFICATE_TYPE_", []string{"CERTIFICATE_TYPE"}},
+	{"SECRET_DATA_TYPE_", []string{"SECRET_DATA_TYPE"}},
+	{"SPLIT_KEY_METHOD_", []string{"SPLIT_KEY_METHOD"}},
+	{"CERTIFICATE_REQUEST_TYPE_", []string{"CERTIFICATE_REQUEST_TYPE"}},
+	{"RECOMMENDED_CURVE_", []string{"RECOMMENDED_CURVE"}},
+}
+
+type constant struct {
+	name  string
+	value uint64
+}
+
+func main() {
+	fset := token.NewFileSet()
+
+	f, err := parser.ParseFile(fset, "consts.go", nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	consts := map[string][]constant{}
+
+	for _, decl := range f.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.CONST {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			valueSpec := spec.(*ast.ValueSpec)
+
+			typ, ok := valueSpec.Type.(*ast.Ident)
+			if !ok {
+				continue
+			}
+
+			for i, name := range valueSpec.Names {
+				lit, ok := valueSpec.Values[i].(*ast.BasicLit)
+				if !ok {
+					log.Fatalf("unexpected value for %s", name.Name)
+				}
+
+				value, err := strconv.ParseUint(lit.Value, 0, 32)
+				if err != nil {
+					log.Fatal(err)
+				}
+
+				consts[typ.Name] = append(consts[typ.Name], constant{name: name.Name, value: value})
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+
+	fmt.Fprintln(&buf, "// Code generated by gen.go; DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "package kmip")
+	fmt.Fprintln(&buf)
+
+	fmt.Fprintln(&buf, "var tagNames = map[Tag]string{")
+
+	seen := map[uint64]bool{}
+
+	for _, c := range consts["Tag"] {
+		if c.name == "ANY_TAG" || seen[c.value] {
+			continue
+		}
+
+		seen[c.value] = true
+
+		fmt.Fprintf(&buf, "%s: %q,\n", c.name, c.name)
+	}
+
+	fmt.Fprintln(&buf, "}")
+	fmt.Fprintln(&buf)
+
+	enums := map[string][]constant{}
+
+	for _, c := range consts["Enum"] {
+		for _, p := range enumPrefixes {
+			if strings.HasPrefix(c.name, p.prefix) {
+				enums[p.prefix] = append(enums[p.prefix], c)
+				break
+			}
+		}
+	}
+
+	fmt.Fprintln(&buf, "var enumNames = map[Tag]map[Enum]string{")
+
+	for _, p := range enumPrefixes {
+		for _, tag := range p.tags {
+			fmt.Fprintf(&buf, "%s: enumNames%s,\n", tag, varName(p.prefix))
+		}
+	}
+
+	fmt.Fprintln(&buf, "}")
+
+	for _, p := range enumPrefixes {
+		if p.tags == nil {
+			continue
+		}
+
+		values := enums[p.prefix]
+		sort.SliceStable(values, func(i, j int) bool { return values[i].value < values[j].value })
+
+		fmt.Fprintln(&buf)
+		fmt.Fprintf(&buf, "var enumNames%s = map[Enum]string{\n", varName(p.prefix))
+
+		seen := map[uint64]bool{}
+
+		for _, c := range values {
+			if seen[c.value] {
+				continue
+			}
+
+			seen[c.value] = true
+
+			fmt.Fprintf(&buf, "%s: %q,\n", c.name, strings.TrimPrefix(c.name, p.prefix))
+		}
+
+		fmt.Fprintln(&buf, "}")
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err = ioutil.WriteFile("consts_string.go", src, 0o644); err != nil { //nolint:gosec
+		log.Fatal(err)
+	}
+}
+
+// varName converts prefix to Go identifier: CRYPTO_ -> Crypto
+func varName(prefix string) string {
+	parts := strings.Split(strings.TrimSuffix(prefix, "_"), "_")
+
+	for i := range parts {
+		parts[i] = parts[i][:1] + strings.ToLower(parts[i][1:])
+	}
+
+	return strings.Join(parts, "")
+}
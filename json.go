@@ -0,0 +1,545 @@
+package kmip
+
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// JSONEncoder implements encoding to KMIP JSON encoding (TTLV-JSON)
+//
+// Values are encoded exactly the same way as with Encoder (using the
+// same `kmip` struct tags), but the output is KMIP JSON:
+//
+//	{"tag":"RequestMessage","type":"Structure","value":[...]}
+type JSONEncoder struct {
+	w io.Writer
+
+	prefix, indent string
+}
+
+// NewJSONEncoder builds JSON encoder writing to w
+func NewJSONEncoder(w io.Writer) *JSONEncoder {
+	return &JSONEncoder{
+		w: w,
+	}
+}
+
+// SetIndent enables indentation of the output (see json.Encoder.SetIndent)
+func (e *JSONEncoder) SetIndent(prefix, indent string) {
+	e.prefix, e.indent = prefix, indent
+}
+
+// Encode v as KMIP JSON
+func (e *JSONEncoder) Encode(v interface{}) error {
+	t, err := toTTLV(v)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(e.w)
+	enc.SetIndent(e.prefix, e.indent)
+
+	return enc.Encode(t)
+}
+
+// JSONDecoder implements decoding from KMIP JSON encoding (TTLV-JSON)
+//
+// Tags might be specified either by name or as hex value ("0x420078"),
+// type might be omitted for structures.
+type JSONDecoder struct {
+	// Relaxed enables order-insensitive, unknown-field-tolerant decoding (see Decoder)
+	Relaxed bool
+
+	d *json.Decoder
+}
+
+// NewJSONDecoder builds JSON decoder which reads from r
+func NewJSONDecoder(r io.Reader) *JSONDecoder {
+	return &JSONDecoder{
+		d: json.NewDecoder(r),
+	}
+}
+
+// Decode KMIP JSON from the reader into v
+func (d *JSONDecoder) Decode(v interface{}) error {
+	var t TTLV
+
+	if err := d.d.Decode(&t); err != nil {
+		return err
+	}
+
+	return fromTTLV(t, v, d.Relaxed)
+}
+
+// toTTLV converts any value (which can be encoded by Encoder) into TTLV
+func toTTLV(v interface{}) (t TTLV, err error) {
+	if tt, ok := v.(TTLV); ok {
+		return tt, nil
+	}
+
+	if tt, ok := v.(*TTLV); ok && tt != nil {
+		return *tt, nil
+	}
+
+	var data []byte
+
+	if data, err = Marshal(v); err != nil {
+		return
+	}
+
+	err = NewDecoder(bytes.NewReader(data)).Decode(&t)
+	return
+}
+
+// fromTTLV converts TTLV into any value (which can be decoded by Decoder)
+func fromTTLV(t TTLV, v interface{}, relaxed bool) error {
+	if tt, ok := v.(*TTLV); ok {
+		*tt = t
+		return nil
+	}
+
+	data, err := Marshal(t)
+	if err != nil {
+		return err
+	}
+
+	d := NewDecoder(bytes.NewReader(data))
+	d.Relaxed = relaxed
+
+	return d.Decode(v)
+}
+
+var tagNormalizedMap = func() map[string]Tag {
+	m := make(map[string]Tag, len(tagMap))
+
+	for name, tag := range tagMap {
+		m[normalizeName(name)] = tag
+	}
+
+	return m
+}()
+
+// normalizeName converts both "REQUEST_MESSAGE" and "RequestMessage" to "requestmessage"
+func normalizeName(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "", " ", "").Replace(name))
+}
+
+// camelCaseName converts tag name to the form used in KMIP JSON & XML encodings: REQUEST_MESSAGE -> RequestMessage
+func camelCaseName(name string) string {
+	parts := strings.Split(name, "_")
+
+	for i := range parts {
+		parts[i] = strings.ToUpper(parts[i][:1]) + strings.ToLower(parts[i][1:])
+	}
+
+	return strings.Join(parts, "")
+}
+
+// tagSpecNames lists KMIP spec names of the tags which contain acronyms
+//
+// Names of other tags are capitalized words of the tag name (see camelCaseName).
+var tagSpecNames = map[Tag]string{
+	CRT_COEFFICIENT:                  "CRTCoefficient",
+	IV_COUNTER_NONCE:                 "IVCounterNonce",
+	MAC_SIGNATURE:                    "MACSignature",
+	MAC_SIGNATURE_KEY_INFORMATION:    "MACSignatureKeyInformation",
+	UNIQUE_BATCH_ITEM_ID:             "UniqueBatchItemID",
+	PGP_KEY:                          "PGPKey",
+	PGP_KEY_VERSION:                  "PGPKeyVersion",
+	RANDOM_IV:                        "RandomIV",
+	MAC_DATA:                         "MACData",
+	NONCE_ID:                         "NonceID",
+	IV_LENGTH:                        "IVLength",
+	DRBG_ALGORITHM:                   "DRBGAlgorithm",
+	FIPS186_VARIATION:                "FIPS186Variation",
+	VALIDATION_AUTHORITY_URI:         "ValidationAuthorityURI",
+	VALIDATION_CERTIFICATE_URI:       "ValidationCertificateURI",
+	VALIDATION_VENDOR_URI:            "ValidationVendorURI",
+	SERVER_URI:                       "ServerURI",
+	PKCS12_FRIENDLY_NAME:             "PKCS12FriendlyName",
+	CERTIFICATE_SUBJECT_CN:           "CertificateSubjectCN",
+	CERTIFICATE_SUBJECT_OU:           "CertificateSubjectOU",
+	CERTIFICATE_SUBJECT_ST:           "CertificateSubjectST",
+	CERTIFICATE_SUBJECT_UID:          "CertificateSubjectUID",
+	CERTIFICATE_SUBJECT_DC:           "CertificateSubjectDC",
+	CERTIFICATE_SUBJECT_DN_QUALIFIER: "CertificateSubjectDNQualifier",
+	CERTIFICATE_ISSUER_CN:            "CertificateIssuerCN",
+	CERTIFICATE_ISSUER_OU:            "CertificateIssuerOU",
+	CERTIFICATE_ISSUER_ST:            "CertificateIssuerST",
+	CERTIFICATE_ISSUER_UID:           "CertificateIssuerUID",
+	CERTIFICATE_ISSUER_DC:            "CertificateIssuerDC",
+	CERTIFICATE_ISSUER_DN_QUALIFIER:  "CertificateIssuerDNQualifier",
+	NIST_KEY_TYPE:                    "NISTKeyType",
+	PKCS11_INTERFACE:                 "PKCS11Interface",
+	PKCS11_FUNCTION:                  "PKCS11Function",
+	PKCS11_INPUT_PARAMETERS:          "PKCS11InputParameters",
+	PKCS11_OUTPUT_PARAMETERS:         "PKCS11OutputParameters",
+	PKCS11_RETURN_CODE:               "PKCS11ReturnCode",
+}
+
+// tagToText converts tag to the text form (spec name or hex value)
+func tagToText(t Tag) string {
+	if name, ok := tagSpecNames[t]; ok {
+		return name
+	}
+
+	if name, ok := tagNames[t]; ok {
+		return camelCaseName(name)
+	}
+
+	return t.String()
+}
+
+// enumNamesTag returns tag which names of enumeration (or mask) value with tag should be looked up with
+//
+// Value names in Attribute Value depend on the attribute, so they are
+// resolved through the Attribute Name, e.g. "Object Type" -> OBJECT_TYPE.
+func enumNamesTag(tag Tag, attributeName string) Tag {
+	if tag == ATTRIBUTE_VALUE && attributeName != "" {
+		if t, ok := tagNormalizedMap[normalizeName(attributeName)]; ok {
+			return t
+		}
+	}
+
+	return tag
+}
+
+// typeToText converts type to the text form: LONG_INTEGER -> LongInteger
+func typeToText(t Type) string {
+	return camelCaseName(t.String())
+}
+
+// tagFromText converts tag from text form (name or hex value)
+func tagFromText(s string) (Tag, error) {
+	if strings.HasPrefix(s, "0x") {
+		v, err := strconv.ParseUint(s[2:], 16, 32)
+		if err != nil {
+			return 0, errors.Wrapf(err, "error parsing tag %q", s)
+		}
+
+		return Tag(v), nil
+	}
+
+	if t, ok := tagNormalizedMap[normalizeName(s)]; ok {
+		return t, nil
+	}
+
+	return 0, errors.Errorf("unknown tag %q", s)
+}
+
+// typeFromText converts type from text form
+func typeFromText(s string) (Type, error) {
+	normalized := normalizeName(s)
+
+	for typ := STRUCTURE; typ <= INTERVAL; typ++ {
+		if normalizeName(typ.String()) == normalized {
+			return typ, nil
+		}
+	}
+
+	return 0, errors.Errorf("unknown type %q", s)
+}
+
+// bigIntToHex encodes big integer as hex string of its TTLV representation
+func bigIntToHex(v *big.Int) string {
+	var e Encoder
+
+	e.writeBigInteger(ANY_TAG, v)
+
+	return "0x" + hex.EncodeToString(e.buf[8:])
+}
+
+// bigIntFromHex decodes big integer from hex string of its TTLV representation
+func bigIntFromHex(s string) (*big.Int, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil, err
+	}
+
+	v := new(big.Int).SetBytes(b)
+
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		var c big.Int
+
+		c.Lsh(big.NewInt(1), uint(len(b)*8))
+		v.Sub(v, &c)
+	}
+
+	return v, nil
+}
+
+type jsonTTLV struct {
+	Tag   string          `json:"tag"`
+	Type  string          `json:"type,omitempty"`
+	Value json.RawMessage `json:"value"`
+}
+
+// MarshalJSON implements json.Marshaler
+//
+// TTLV is encoded according to KMIP JSON encoding.
+func (t TTLV) MarshalJSON() ([]byte, error) {
+	var (
+		v   interface{}
+		err error
+	)
+
+	ok := true
+
+	switch t.Type {
+	case STRUCTURE:
+		children := t.Children
+		if children == nil {
+			children = []TTLV{}
+		}
+
+		v = children
+	case INTEGER:
+		v, ok = t.Value.(int32)
+	case LONG_INTEGER:
+		var i int64
+		if i, ok = t.Value.(int64); ok {
+			if i > 1<<52 || i < -(1<<52) {
+				v = fmt.Sprintf("0x%016x", uint64(i))
+			} else {
+				v = i
+			}
+		}
+	case BIG_INTEGER:
+		var i *big.Int
+		if i, ok = t.Value.(*big.Int); ok && i != nil {
+			v = bigIntToHex(i)
+		} else {
+			ok = false
+		}
+	case ENUMERATION:
+		var e Enum
+		if e, ok = t.Value.(Enum); ok {
+			v = fmt.Sprintf("0x%08x", uint32(e))
+		}
+	case BOOLEAN:
+		v, ok = t.Value.(bool)
+	case TEXT_STRING:
+		v, ok = t.Value.(string)
+	case BYTE_STRING:
+		var b []byte
+		if b, ok = t.Value.([]byte); ok {
+			v = hex.EncodeToString(b)
+		}
+	case DATE_TIME:
+		var tm time.Time
+		if tm, ok = t.Value.(time.Time); ok {
+			v = tm.UTC().Format(time.RFC3339)
+		}
+	case INTERVAL:
+		var d time.Duration
+		if d, ok = t.Value.(time.Duration); ok {
+			v = int64(d / time.Second)
+		}
+	default:
+		err = errors.Errorf("unsupported type %d for tag %x", t.Type, uint32(t.Tag))
+	}
+
+	if !ok {
+		err = errors.Errorf("unexpected value %T for type %d, tag %x", t.Value, t.Type, uint32(t.Tag))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := jsonTTLV{
+		Tag:  tagToText(t.Tag),
+		Type: typeToText(t.Type),
+	}
+
+	if res.Value, err = json.Marshal(v); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(res)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+//
+// TTLV is decoded according to KMIP JSON encoding.
+func (t *TTLV) UnmarshalJSON(data []byte) error {
+	return t.unmarshalJSON(data, "")
+}
+
+// unmarshalJSON decodes TTLV, attributeName is the name of the enclosing attribute (if any)
+func (t *TTLV) unmarshalJSON(data []byte, attributeName string) (err error) {
+	var v jsonTTLV
+
+	if err = json.Unmarshal(data, &v); err != nil {
+		return
+	}
+
+	if t.Tag, err = tagFromText(v.Tag); err != nil {
+		return
+	}
+
+	t.Type = STRUCTURE
+	if v.Type != "" {
+		if t.Type, err = typeFromText(v.Type); err != nil {
+			return
+		}
+	}
+
+	t.Value, t.Children = nil, nil
+
+	var s string
+
+	isString := len(v.Value) > 0 && v.Value[0] == '"'
+	if isString {
+		if err = json.Unmarshal(v.Value, &s); err != nil {
+			return
+		}
+	}
+
+	switch t.Type {
+	case STRUCTURE:
+		var children []json.RawMessage
+
+		if err = json.Unmarshal(v.Value, &children); err != nil || children == nil {
+			break
+		}
+
+		t.Children = make([]TTLV, len(children))
+
+		var name string
+
+		for i := range children {
+			if err = t.Children[i].unmarshalJSON(children[i], name); err != nil {
+				break
+			}
+
+			if t.Children[i].Tag == ATTRIBUTE_NAME {
+				name, _ = t.Children[i].Value.(string)
+			}
+		}
+	case INTEGER:
+		var i int64
+
+		switch {
+		case isString && enumNamesTag(t.Tag, attributeName) == CRYPTOGRAPHIC_USAGE_MASK:
+			var m UsageMask
+
+			m, err = parseUsageMask(s)
+			i = int64(int32(m))
+		case isString:
+			i, err = parseHexInt(s, 32)
+		default:
+			err = json.Unmarshal(v.Value, &i)
+		}
+
+		if err == nil && (i > math.MaxInt32 || i < math.MinInt32) {
+			err = errors.Errorf("integer value out of range: %d", i)
+		}
+
+		t.Value = int32(i)
+	case LONG_INTEGER:
+		var i int64
+
+		if isString {
+			i, err = parseHexInt(s, 64)
+		} else {
+			err = json.Unmarshal(v.Value, &i)
+		}
+
+		t.Value = i
+	case BIG_INTEGER:
+		if isString {
+			t.Value, err = bigIntFromHex(s)
+		} else {
+			var i int64
+
+			err = json.Unmarshal(v.Value, &i)
+			t.Value = big.NewInt(i)
+		}
+	case ENUMERATION:
+		if isString {
+			t.Value, err = ParseEnum(enumNamesTag(t.Tag, attributeName), s)
+		} else {
+			var i uint32
+
+			err = json.Unmarshal(v.Value, &i)
+			t.Value = Enum(i)
+		}
+	case BOOLEAN:
+		var b bool
+
+		err = json.Unmarshal(v.Value, &b)
+		t.Value = b
+	case TEXT_STRING:
+		var str string
+
+		err = json.Unmarshal(v.Value, &str)
+		t.Value = str
+	case BYTE_STRING:
+		var b []byte
+
+		if isString {
+			b, err = hex.DecodeString(s)
+		} else {
+			err = errors.Errorf("hex string expected: %s", v.Value)
+		}
+
+		t.Value = b
+	case DATE_TIME:
+		var tm time.Time
+
+		if strings.HasPrefix(s, "0x") {
+			var i int64
+
+			i, err = parseHexInt(s, 64)
+			tm = time.Unix(i, 0)
+		} else {
+			tm, err = time.Parse(time.RFC3339, s)
+		}
+
+		t.Value = tm
+	case INTERVAL:
+		var i uint32
+
+		err = json.Unmarshal(v.Value, &i)
+		t.Value = time.Duration(i) * time.Second
+	}
+
+	if err != nil {
+		err = errors.Wrapf(err, "error decoding value for tag %s", v.Tag)
+	}
+
+	return
+}
+
+// parseHexInt parses "0x..." hex value as two's complement integer of given bit size
+func parseHexInt(s string, bitSize int) (int64, error) {
+	if !strings.HasPrefix(s, "0x") {
+		return 0, errors.Errorf("hex value expected: %q", s)
+	}
+
+	v, err := strconv.ParseUint(s[2:], 16, bitSize)
+	if err != nil {
+		return 0, err
+	}
+
+	if bitSize == 32 {
+		return int64(int32(v)), nil
+	}
+
+	return int64(v), nil
+}
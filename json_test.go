@@ -0,0 +1,215 @@
+package kmip
+
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type JSONSuite struct {
+	suite.Suite
+}
+
+const jsonMessageGet = `{"tag":"RequestMessage","type":"Structure","value":[` +
+	`{"tag":"RequestHeader","type":"Structure","value":[` +
+	`{"tag":"ProtocolVersion","type":"Structure","value":[` +
+	`{"tag":"ProtocolVersionMajor","type":"Integer","value":1},` +
+	`{"tag":"ProtocolVersionMinor","type":"Integer","value":1}]},` +
+	`{"tag":"BatchCount","type":"Integer","value":1}]},` +
+	`{"tag":"BatchItem","type":"Structure","value":[` +
+	`{"tag":"Operation","type":"Enumeration","value":"0x0000000a"},` +
+	`{"tag":"RequestPayload","type":"Structure","value":[` +
+	`{"tag":"UniqueIdentifier","type":"TextString","value":"49a1ca88-6bea-4fb2-b450-7e58802c3038"}]}]}]}` + "\n"
+
+func (s *JSONSuite) TestEncodeMessageGet() {
+	var m Request
+
+	s.Require().NoError(NewDecoder(bytes.NewReader(messageGet)).Decode(&m))
+
+	var buf bytes.Buffer
+
+	s.Require().NoError(NewJSONEncoder(&buf).Encode(&m))
+	s.Assert().Equal(jsonMessageGet, buf.String())
+}
+
+func (s *JSONSuite) TestDecodeMessageGet() {
+	var m Request
+
+	s.Require().NoError(NewJSONDecoder(strings.NewReader(jsonMessageGet)).Decode(&m))
+	s.Assert().Equal(Request{
+		Header: RequestHeader{
+			Version:    ProtocolVersion{Major: 1, Minor: 1},
+			BatchCount: 1,
+		},
+		BatchItems: []RequestBatchItem{
+			{
+				Operation: OPERATION_GET,
+				RequestPayload: GetRequest{
+					UniqueIdentifier: "49a1ca88-6bea-4fb2-b450-7e58802c3038",
+				},
+			},
+		},
+	}, m)
+}
+
+func (s *JSONSuite) TestDecodeAlternativeForms() {
+	// hex tags, missing structure type, decimal enum, upper snake case names
+	const message = `{"tag":"0x420078","value":[` +
+		`{"tag":"REQUEST_HEADER","value":[` +
+		`{"tag":"ProtocolVersion","value":[` +
+		`{"tag":"ProtocolVersionMajor","type":"Integer","value":"0x00000001"},` +
+		`{"tag":"ProtocolVersionMinor","type":"Integer","value":1}]},` +
+		`{"tag":"BatchCount","type":"Integer","value":1}]},` +
+		`{"tag":"BatchItem","value":[` +
+		`{"tag":"Operation","type":"Enumeration","value":10},` +
+		`{"tag":"RequestPayload","value":[` +
+		`{"tag":"UniqueIdentifier","type":"TextString","value":"49a1ca88-6bea-4fb2-b450-7e58802c3038"}]}]}]}`
+
+	var t TTLV
+
+	s.Require().NoError(NewJSONDecoder(strings.NewReader(message)).Decode(&t))
+
+	var buf bytes.Buffer
+
+	s.Require().NoError(NewEncoder(&buf).Encode(t))
+	s.Assert().Equal(messageGet, buf.Bytes())
+}
+
+func (s *JSONSuite) TestDecodeEnumNames() {
+	const message = `[{"tag":"Operation","type":"Enumeration","value":"Get"},` +
+		`{"tag":"CryptographicAlgorithm","type":"enumeration","value":"AES"},` +
+		`{"tag":"ObjectType","type":"Enumeration","value":"SYMMETRIC_KEY"}]`
+
+	var t []TTLV
+
+	s.Require().NoError(json.Unmarshal([]byte(message), &t))
+	s.Require().Len(t, 3)
+	s.Assert().Equal(OPERATION_GET, t[0].Value)
+	s.Assert().Equal(CRYPTO_AES, t[1].Value)
+	s.Assert().Equal(OBJECT_TYPE_SYMMETRIC_KEY, t[2].Value)
+}
+
+func (s *JSONSuite) TestDecodeAttributeEnumNames() {
+	const message = `{"tag":"Attribute","value":[` +
+		`{"tag":"AttributeName","type":"TextString","value":"Object Type"},` +
+		`{"tag":"AttributeValue","type":"Enumeration","value":"SymmetricKey"}]}`
+
+	var t TTLV
+
+	s.Require().NoError(json.Unmarshal([]byte(message), &t))
+	s.Require().Len(t.Children, 2)
+	s.Assert().Equal(OBJECT_TYPE_SYMMETRIC_KEY, t.Children[1].Value)
+
+	err := json.Unmarshal([]byte(`{"tag":"AttributeValue","type":"Enumeration","value":"SymmetricKey"}`), &t)
+	s.Assert().EqualError(err, `error decoding value for tag AttributeValue: unknown enumeration value "SymmetricKey" for tag ATTRIBUTE_VALUE`)
+}
+
+func (s *JSONSuite) TestDecodeUsageMaskNames() {
+	const message = `{"tag":"TemplateAttribute","value":[` +
+		`{"tag":"Attribute","value":[` +
+		`{"tag":"AttributeName","type":"TextString","value":"Cryptographic Usage Mask"},` +
+		`{"tag":"AttributeValue","type":"Integer","value":"Encrypt|Decrypt"}]}]}`
+
+	var ta TemplateAttribute
+
+	s.Require().NoError(NewJSONDecoder(strings.NewReader(message)).Decode(&ta))
+
+	mask, ok := ta.Attributes.GetUsageMask()
+	s.Require().True(ok)
+	s.Assert().Equal(CRYPTO_USAGE_MASK_ENCRYPT|CRYPTO_USAGE_MASK_DECRYPT, mask)
+
+	var t TTLV
+
+	s.Require().NoError(json.Unmarshal([]byte(`{"tag":"CryptographicUsageMask","type":"Integer","value":"Sign|0x00000100"}`), &t))
+	s.Assert().Equal(int32(CRYPTO_USAGE_MASK_SIGN|CRYPTO_USAGE_MASK_MAC_VERIFY), t.Value)
+
+	err := json.Unmarshal([]byte(`{"tag":"CryptographicUsageMask","type":"Integer","value":"Encrypt|Frobnicate"}`), &t)
+	s.Assert().EqualError(err, `error decoding value for tag CryptographicUsageMask: unknown usage mask value "Frobnicate"`)
+}
+
+func (s *JSONSuite) TestSpecTagNames() {
+	t := TTLV{
+		Tag:  PGP_KEY,
+		Type: STRUCTURE,
+		Children: []TTLV{
+			{Tag: MAC_SIGNATURE, Type: BYTE_STRING, Value: []byte{1}},
+			{Tag: UNIQUE_BATCH_ITEM_ID, Type: BYTE_STRING, Value: []byte{2}},
+		},
+	}
+
+	data, err := json.Marshal(t)
+	s.Require().NoError(err)
+	s.Assert().Equal(`{"tag":"PGPKey","type":"Structure","value":[`+
+		`{"tag":"MACSignature","type":"ByteString","value":"01"},`+
+		`{"tag":"UniqueBatchItemID","type":"ByteString","value":"02"}]}`, string(data))
+
+	var t2 TTLV
+
+	s.Require().NoError(json.Unmarshal(data, &t2))
+	s.Assert().Equal(t, t2)
+}
+
+func (s *JSONSuite) TestAllTypes() {
+	t := TTLV{
+		Tag:  COMPROMISE_DATE,
+		Type: STRUCTURE,
+		Children: []TTLV{
+			{Tag: ARCHIVE_DATE, Type: INTEGER, Value: int32(-5)},
+			{Tag: ARCHIVE_DATE, Type: LONG_INTEGER, Value: int64(123456789000000000)},
+			{Tag: ARCHIVE_DATE, Type: LONG_INTEGER, Value: int64(-10)},
+			{Tag: ARCHIVE_DATE, Type: BIG_INTEGER, Value: big.NewInt(-129)},
+			{Tag: ARCHIVE_DATE, Type: ENUMERATION, Value: Enum(255)},
+			{Tag: ARCHIVE_DATE, Type: BOOLEAN, Value: true},
+			{Tag: ARCHIVE_DATE, Type: TEXT_STRING, Value: "Hello World"},
+			{Tag: ARCHIVE_DATE, Type: BYTE_STRING, Value: []byte{1, 2, 3}},
+			{Tag: ARCHIVE_DATE, Type: DATE_TIME, Value: time.Date(2008, 3, 14, 11, 56, 40, 0, time.UTC)},
+			{Tag: ARCHIVE_DATE, Type: INTERVAL, Value: 10 * 24 * time.Hour},
+			{Tag: 0x540001, Type: STRUCTURE, Children: []TTLV{}},
+		},
+	}
+
+	data, err := json.Marshal(t)
+	s.Require().NoError(err)
+
+	s.Assert().Equal(`{"tag":"CompromiseDate","type":"Structure","value":[`+
+		`{"tag":"ArchiveDate","type":"Integer","value":-5},`+
+		`{"tag":"ArchiveDate","type":"LongInteger","value":"0x01b69b4ba5749200"},`+
+		`{"tag":"ArchiveDate","type":"LongInteger","value":-10},`+
+		`{"tag":"ArchiveDate","type":"BigInteger","value":"0xffffffffffffff7f"},`+
+		`{"tag":"ArchiveDate","type":"Enumeration","value":"0x000000ff"},`+
+		`{"tag":"ArchiveDate","type":"Boolean","value":true},`+
+		`{"tag":"ArchiveDate","type":"TextString","value":"Hello World"},`+
+		`{"tag":"ArchiveDate","type":"ByteString","value":"010203"},`+
+		`{"tag":"ArchiveDate","type":"DateTime","value":"2008-03-14T11:56:40Z"},`+
+		`{"tag":"ArchiveDate","type":"Interval","value":864000},`+
+		`{"tag":"0x540001","type":"Structure","value":[]}]}`, string(data))
+
+	var t2 TTLV
+
+	s.Require().NoError(json.Unmarshal(data, &t2))
+	s.Assert().Equal(t, t2)
+}
+
+func (s *JSONSuite) TestDecodeErrors() {
+	var t TTLV
+
+	s.Assert().EqualError(json.Unmarshal([]byte(`{"tag":"NoSuchTag","value":[]}`), &t), `unknown tag "NoSuchTag"`)
+	s.Assert().EqualError(json.Unmarshal([]byte(`{"tag":"ArchiveDate","type":"Float","value":1}`), &t), `unknown type "Float"`)
+	s.Assert().EqualError(json.Unmarshal([]byte(`{"tag":"ArchiveDate","type":"Integer","value":"12"}`), &t),
+		`error decoding value for tag ArchiveDate: hex value expected: "12"`)
+	s.Assert().EqualError(json.Unmarshal([]byte(`{"tag":"ArchiveDate","type":"ByteString","value":[1,2]}`), &t),
+		`error decoding value for tag ArchiveDate: hex string expected: [1,2]`)
+}
+
+func TestJSONSuite(t *testing.T) {
+	suite.Run(t, new(JSONSuite))
+}
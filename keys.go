@@ -4,6 +4,14 @@ package kmip
  * License, v. 2.0. If a copy of the MPL was not distributed with this
  * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
 
+import (
+	"bytes"
+	"math/big"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
 // KeyWrappingSpecification is a Key Wrapping Specification Object
 type KeyWrappingSpecification struct {
 	Tag `kmip:"KEY_WRAPPING_SPECIFICATION"`
@@ -88,6 +96,26 @@ type PrivateKey struct {
 	KeyBlock KeyBlock `kmip:"KEY_BLOCK,required"`
 }
 
+// SplitKey is a Managed Cryptographic Object that is a part of a key split into multiple parts
+type SplitKey struct {
+	Tag `kmip:"SPLIT_KEY"`
+
+	SplitKeyParts     int32    `kmip:"SPLIT_KEY_PARTS,required"`
+	KeyPartIdentifier int32    `kmip:"KEY_PART_IDENTIFIER,required"`
+	SplitKeyThreshold int32    `kmip:"SPLIT_KEY_THRESHOLD,required"`
+	SplitKeyMethod    Enum     `kmip:"SPLIT_KEY_METHOD,required"`
+	PrimeFieldSize    *big.Int `kmip:"PRIME_FIELD_SIZE"`
+	KeyBlock          KeyBlock `kmip:"KEY_BLOCK,required"`
+}
+
+// PGPKey is a Managed Cryptographic Object that is a text-based representation of a PGP key
+type PGPKey struct {
+	Tag `kmip:"PGP_KEY"`
+
+	PGPKeyVersion int32    `kmip:"PGP_KEY_VERSION,required"`
+	KeyBlock      KeyBlock `kmip:"KEY_BLOCK,required"`
+}
+
 // KeyBlock is a Key Block Object Structure
 type KeyBlock struct {
 	Tag `kmip:"KEY_BLOCK"`
@@ -100,11 +128,198 @@ type KeyBlock struct {
 	WrappingData           KeyWrappingData `kmip:"KEY_WRAPPING_SPECIFICATION"`
 }
 
+// MarshalTTLV implements Marshaler interface
+func (kb KeyBlock) MarshalTTLV(e *Encoder, tag Tag) error {
+	if tag == ANY_TAG {
+		tag = KEY_BLOCK
+	}
+
+	type keyBlock KeyBlock
+
+	return e.EncodeValue(tag, keyBlock(kb))
+}
+
+// UnmarshalTTLV implements Unmarshaler interface
+//
+// Key Material is decoded according to the FormatType, see RegisterKeyMaterial.
+func (kb *KeyBlock) UnmarshalTTLV(d *Decoder, tag Tag) (err error) {
+	if tag == ANY_TAG {
+		tag = KEY_BLOCK
+	}
+
+	type keyBlock KeyBlock
+
+	var b keyBlock
+
+	if err = d.DecodeValue(tag, &b); err != nil {
+		return
+	}
+
+	*kb = KeyBlock(b)
+
+	return kb.Value.buildKeyMaterial(d, kb.FormatType)
+}
+
 // KeyValue is a Key Value Object Structure
 type KeyValue struct {
 	Tag `kmip:"KEY_VALUE"`
 
-	// TODO: might be structure if wrapping is used
-	KeyMaterial []byte     `kmip:"KEY_MATERIAL"`
-	Attributes  Attributes `kmip:"ATTRIBUTE"`
+	// KeyMaterial is []byte for raw and encoded key formats (e.g. KEY_FORMAT_RAW,
+	// KEY_FORMAT_PKCS_1), for transparent key formats it's one of the Transparent*
+	// structures (e.g. TransparentRSAPrivateKey) depending on the KeyBlock.FormatType
+	//
+	// Earlier versions declared KeyMaterial as []byte, so existing code should
+	// use type assertion KeyMaterial.([]byte) for raw and encoded key formats.
+	//
+	// TODO: Key Value might be Byte String if wrapping is used
+	KeyMaterial interface{} `kmip:"KEY_MATERIAL"`
+	Attributes  Attributes  `kmip:"ATTRIBUTE"`
+}
+
+// BuildFieldValue implements DynamicDispatch
+//
+// KeyMaterial is decoded according to the type on the wire, structures are
+// converted to typed values by KeyBlock once FormatType is known.
+func (kv *KeyValue) BuildFieldValue(name string) (v interface{}, err error) {
+	return
+}
+
+// buildKeyMaterial converts structure Key Material into the value of the type registered for the format
+func (kv *KeyValue) buildKeyMaterial(d *Decoder, formatType Enum) error {
+	t, ok := kv.KeyMaterial.(TTLV)
+	if !ok {
+		return nil
+	}
+
+	typ, ok := keyMaterialRegistry[formatType]
+	if !ok {
+		return nil
+	}
+
+	raw, err := Marshal(t)
+	if err != nil {
+		return err
+	}
+
+	v := reflect.New(typ.Elem())
+
+	if err = d.child(bytes.NewReader(raw)).DecodeValue(KEY_MATERIAL, v.Interface()); err != nil {
+		return errors.WithMessagef(err, "error decoding key material for format %s", EnumName(KEY_FORMAT_TYPE, formatType))
+	}
+
+	kv.KeyMaterial = v.Elem().Interface()
+
+	return nil
+}
+
+// TransparentSymmetricKey is a Key Material for KEY_FORMAT_TRANSPARENT_SYMMETRIC_KEY
+type TransparentSymmetricKey struct {
+	Tag `kmip:"KEY_MATERIAL"`
+
+	Key []byte `kmip:"KEY,required"`
+}
+
+// TransparentDSAPrivateKey is a Key Material for KEY_FORMAT_TRANSPARENT_DSA_PRIVATE_KEY
+type TransparentDSAPrivateKey struct {
+	Tag `kmip:"KEY_MATERIAL"`
+
+	P *big.Int `kmip:"P,required"`
+	Q *big.Int `kmip:"Q,required"`
+	G *big.Int `kmip:"G,required"`
+	X *big.Int `kmip:"X,required"`
+}
+
+// TransparentDSAPublicKey is a Key Material for KEY_FORMAT_TRANSPARENT_DSA_PUBLIC_KEY
+type TransparentDSAPublicKey struct {
+	Tag `kmip:"KEY_MATERIAL"`
+
+	P *big.Int `kmip:"P,required"`
+	Q *big.Int `kmip:"Q,required"`
+	G *big.Int `kmip:"G,required"`
+	Y *big.Int `kmip:"Y,required"`
+}
+
+// TransparentRSAPrivateKey is a Key Material for KEY_FORMAT_TRANSPARENT_RSA_PRIVATE_KEY
+//
+// Besides Modulus, either PrivateExponent or the CRT components (P, Q,
+// PrimeExponentP, PrimeExponentQ and CRTCoefficient) should be present.
+type TransparentRSAPrivateKey struct {
+	Tag `kmip:"KEY_MATERIAL"`
+
+	Modulus         *big.Int `kmip:"MODULUS,required"`
+	PrivateExponent *big.Int `kmip:"PRIVATE_EXPONENT"`
+	PublicExponent  *big.Int `kmip:"PUBLIC_EXPONENT"`
+	P               *big.Int `kmip:"P"`
+	Q               *big.Int `kmip:"Q"`
+	PrimeExponentP  *big.Int `kmip:"PRIME_EXPONENT_P"`
+	PrimeExponentQ  *big.Int `kmip:"PRIME_EXPONENT_Q"`
+	CRTCoefficient  *big.Int `kmip:"CRT_COEFFICIENT"`
+}
+
+// TransparentRSAPublicKey is a Key Material for KEY_FORMAT_TRANSPARENT_RSA_PUBLIC_KEY
+type TransparentRSAPublicKey struct {
+	Tag `kmip:"KEY_MATERIAL"`
+
+	Modulus        *big.Int `kmip:"MODULUS,required"`
+	PublicExponent *big.Int `kmip:"PUBLIC_EXPONENT,required"`
+}
+
+// TransparentDHPrivateKey is a Key Material for KEY_FORMAT_TRANSPARENT_DH_PRIVATE_KEY
+type TransparentDHPrivateKey struct {
+	Tag `kmip:"KEY_MATERIAL"`
+
+	P *big.Int `kmip:"P,required"`
+	Q *big.Int `kmip:"Q"`
+	G *big.Int `kmip:"G,required"`
+	J *big.Int `kmip:"J"`
+	X *big.Int `kmip:"X,required"`
+}
+
+// TransparentDHPublicKey is a Key Material for KEY_FORMAT_TRANSPARENT_DH_PUBLIC_KEY
+type TransparentDHPublicKey struct {
+	Tag `kmip:"KEY_MATERIAL"`
+
+	P *big.Int `kmip:"P,required"`
+	Q *big.Int `kmip:"Q"`
+	G *big.Int `kmip:"G,required"`
+	J *big.Int `kmip:"J"`
+	Y *big.Int `kmip:"Y,required"`
+}
+
+// TransparentECPrivateKey is a Key Material for KEY_FORMAT_TRANSPARENT_EC_PRIVATE_KEY
+//
+// Deprecated ECDSA, ECDH and ECMQV private key formats share the same structure.
+type TransparentECPrivateKey struct {
+	Tag `kmip:"KEY_MATERIAL"`
+
+	RecommendedCurve Enum     `kmip:"RECOMMENDED_CURVE,required"`
+	D                *big.Int `kmip:"D,required"`
+}
+
+// TransparentECPublicKey is a Key Material for KEY_FORMAT_TRANSPARENT_EC_PUBLIC_KEY
+//
+// Deprecated ECDSA, ECDH and ECMQV public key formats share the same structure.
+type TransparentECPublicKey struct {
+	Tag `kmip:"KEY_MATERIAL"`
+
+	RecommendedCurve Enum   `kmip:"RECOMMENDED_CURVE,required"`
+	QString          []byte `kmip:"Q_STRING,required"`
+}
+
+func init() {
+	RegisterKeyMaterial(KEY_FORMAT_TRANSPARENT_SYMMETRIC_KEY, &TransparentSymmetricKey{})
+	RegisterKeyMaterial(KEY_FORMAT_TRANSPARENT_DSA_PRIVATE_KEY, &TransparentDSAPrivateKey{})
+	RegisterKeyMaterial(KEY_FORMAT_TRANSPARENT_DSA_PUBLIC_KEY, &TransparentDSAPublicKey{})
+	RegisterKeyMaterial(KEY_FORMAT_TRANSPARENT_RSA_PRIVATE_KEY, &TransparentRSAPrivateKey{})
+	RegisterKeyMaterial(KEY_FORMAT_TRANSPARENT_RSA_PUBLIC_KEY, &TransparentRSAPublicKey{})
+	RegisterKeyMaterial(KEY_FORMAT_TRANSPARENT_DH_PRIVATE_KEY, &TransparentDHPrivateKey{})
+	RegisterKeyMaterial(KEY_FORMAT_TRANSPARENT_DH_PUBLIC_KEY, &TransparentDHPublicKey{})
+	RegisterKeyMaterial(KEY_FORMAT_TRANSPARENT_ECDSA_PRIVATE_KEY, &TransparentECPrivateKey{})
+	RegisterKeyMaterial(KEY_FORMAT_TRANSPARENT_ECDSA_PUBLIC_KEY, &TransparentECPublicKey{})
+	RegisterKeyMaterial(KEY_FORMAT_TRANSPARENT_ECDH_PRIVATE_KEY, &TransparentECPrivateKey{})
+	RegisterKeyMaterial(KEY_FORMAT_TRANSPARENT_ECDH_PUBLIC_KEY, &TransparentECPublicKey{})
+	RegisterKeyMaterial(KEY_FORMAT_TRANSPARENT_ECMQV_PRIVATE_KEY, &TransparentECPrivateKey{})
+	RegisterKeyMaterial(KEY_FORMAT_TRANSPARENT_ECMQV_PUBLIC_KEY, &TransparentECPublicKey{})
+	RegisterKeyMaterial(KEY_FORMAT_TRANSPARENT_EC_PRIVATE_KEY, &TransparentECPrivateKey{})
+	RegisterKeyMaterial(KEY_FORMAT_TRANSPARENT_EC_PUBLIC_KEY, &TransparentECPublicKey{})
 }
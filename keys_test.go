@@ -0,0 +1,204 @@
+package kmip
+
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type testVendorKeyMaterial struct {
+	Tag `kmip:"KEY_MATERIAL"`
+
+	Key []byte `kmip:"KEY,required"`
+}
+
+const testVendorKeyFormat Enum = 0x80000010
+
+func init() {
+	RegisterKeyMaterial(testVendorKeyFormat, &testVendorKeyMaterial{})
+}
+
+type KeysSuite struct {
+	suite.Suite
+}
+
+func (s *KeysSuite) roundTrip(resp GetResponse) GetResponse {
+	data, err := Marshal(&resp)
+	s.Require().NoError(err)
+
+	var decoded GetResponse
+	s.Require().NoError(NewDecoder(bytes.NewReader(data)).Decode(&decoded))
+
+	return decoded
+}
+
+func (s *KeysSuite) TestTransparentKeyMaterial() {
+	for _, kb := range []KeyBlock{
+		{
+			FormatType: KEY_FORMAT_TRANSPARENT_SYMMETRIC_KEY,
+			Value: KeyValue{
+				KeyMaterial: TransparentSymmetricKey{Key: []byte{0x01, 0x02, 0x03, 0x04}},
+			},
+			CryptographicAlgorithm: CRYPTO_AES,
+			CryptographicLength:    32,
+		},
+		{
+			FormatType: KEY_FORMAT_TRANSPARENT_RSA_PRIVATE_KEY,
+			Value: KeyValue{
+				KeyMaterial: TransparentRSAPrivateKey{
+					Modulus:         big.NewInt(3233),
+					PrivateExponent: big.NewInt(413),
+					PublicExponent:  big.NewInt(17),
+					P:               big.NewInt(61),
+					Q:               big.NewInt(53),
+				},
+			},
+			CryptographicAlgorithm: CRYPTO_RSA,
+			CryptographicLength:    12,
+		},
+		{
+			FormatType: KEY_FORMAT_TRANSPARENT_RSA_PUBLIC_KEY,
+			Value: KeyValue{
+				KeyMaterial: TransparentRSAPublicKey{Modulus: big.NewInt(3233), PublicExponent: big.NewInt(17)},
+			},
+		},
+		{
+			FormatType: KEY_FORMAT_TRANSPARENT_DSA_PUBLIC_KEY,
+			Value: KeyValue{
+				KeyMaterial: TransparentDSAPublicKey{P: big.NewInt(23), Q: big.NewInt(11), G: big.NewInt(4), Y: big.NewInt(8)},
+			},
+		},
+		{
+			FormatType: KEY_FORMAT_TRANSPARENT_DH_PRIVATE_KEY,
+			Value: KeyValue{
+				KeyMaterial: TransparentDHPrivateKey{P: big.NewInt(23), G: big.NewInt(5), X: big.NewInt(6)},
+			},
+		},
+		{
+			FormatType: KEY_FORMAT_TRANSPARENT_EC_PRIVATE_KEY,
+			Value: KeyValue{
+				KeyMaterial: TransparentECPrivateKey{RecommendedCurve: RECOMMENDED_CURVE_P_256, D: big.NewInt(12345)},
+			},
+		},
+		{
+			FormatType: KEY_FORMAT_TRANSPARENT_ECDSA_PUBLIC_KEY,
+			Value: KeyValue{
+				KeyMaterial: TransparentECPublicKey{RecommendedCurve: RECOMMENDED_CURVE_P_256, QString: []byte{0x04, 0x01, 0x02}},
+			},
+		},
+		{
+			FormatType: testVendorKeyFormat,
+			Value: KeyValue{
+				KeyMaterial: testVendorKeyMaterial{Key: []byte("vendor")},
+			},
+		},
+	} {
+		resp := GetResponse{
+			ObjectType:       OBJECT_TYPE_PRIVATE_KEY,
+			UniqueIdentifier: "1",
+			PrivateKey:       PrivateKey{KeyBlock: kb},
+		}
+
+		s.Run(EnumName(KEY_FORMAT_TYPE, kb.FormatType), func() {
+			s.Assert().Equal(resp, s.roundTrip(resp))
+		})
+	}
+}
+
+func (s *KeysSuite) TestRawKeyMaterial() {
+	resp := GetResponse{
+		ObjectType:       OBJECT_TYPE_SYMMETRIC_KEY,
+		UniqueIdentifier: "1",
+		SymmetricKey: SymmetricKey{
+			KeyBlock: KeyBlock{
+				FormatType: KEY_FORMAT_RAW,
+				Value: KeyValue{
+					KeyMaterial: []byte{0x01, 0x02, 0x03, 0x04},
+					Attributes: Attributes{
+						{Name: ATTRIBUTE_NAME_CRYPTOGRAPHIC_LENGTH, Value: int32(32)},
+					},
+				},
+			},
+		},
+	}
+
+	s.Assert().Equal(resp, s.roundTrip(resp))
+}
+
+func (s *KeysSuite) TestUnknownKeyMaterial() {
+	resp := GetResponse{
+		ObjectType:       OBJECT_TYPE_PUBLIC_KEY,
+		UniqueIdentifier: "1",
+		PublicKey: PublicKey{
+			KeyBlock: KeyBlock{
+				FormatType: Enum(0x80000011),
+				Value: KeyValue{
+					KeyMaterial: testVendorKeyMaterial{Key: []byte("vendor")},
+				},
+			},
+		},
+	}
+
+	decoded := s.roundTrip(resp)
+	s.Assert().Equal(TTLV{
+		Tag:  KEY_MATERIAL,
+		Type: STRUCTURE,
+		Children: []TTLV{
+			{Tag: KEY, Type: BYTE_STRING, Value: []byte("vendor")},
+		},
+	}, decoded.PublicKey.KeyBlock.Value.KeyMaterial)
+}
+
+func (s *KeysSuite) TestRegisterRequest() {
+	req := RegisterRequest{
+		ObjectType: OBJECT_TYPE_PUBLIC_KEY,
+		PublicKey: PublicKey{
+			KeyBlock: KeyBlock{
+				FormatType: KEY_FORMAT_TRANSPARENT_RSA_PUBLIC_KEY,
+				Value: KeyValue{
+					KeyMaterial: TransparentRSAPublicKey{Modulus: big.NewInt(3233), PublicExponent: big.NewInt(17)},
+				},
+			},
+		},
+	}
+
+	data, err := Marshal(&req)
+	s.Require().NoError(err)
+
+	var decoded RegisterRequest
+	s.Require().NoError(NewDecoder(bytes.NewReader(data)).Decode(&decoded))
+	s.Assert().Equal(req, decoded)
+}
+
+func (s *KeysSuite) TestMalformedKeyMaterial() {
+	resp := GetResponse{
+		ObjectType:       OBJECT_TYPE_PUBLIC_KEY,
+		UniqueIdentifier: "1",
+		PublicKey: PublicKey{
+			KeyBlock: KeyBlock{
+				FormatType: KEY_FORMAT_TRANSPARENT_RSA_PUBLIC_KEY,
+				Value: KeyValue{
+					KeyMaterial: TransparentSymmetricKey{Key: []byte{0x01}},
+				},
+			},
+		},
+	}
+
+	data, err := Marshal(&resp)
+	s.Require().NoError(err)
+
+	var decoded GetResponse
+	s.Assert().EqualError(NewDecoder(bytes.NewReader(data)).Decode(&decoded),
+		"error decoding 0x000000/PublicKey/KeyBlock at offset 48: error decoding key material for format TRANSPARENT_RSA_PUBLIC_KEY: "+
+			"error decoding Modulus at offset 168: expecting tag 420052, but 42003f was encountered")
+}
+
+func TestKeysSuite(t *testing.T) {
+	suite.Run(t, new(KeysSuite))
+}
@@ -0,0 +1,122 @@
+package kmip
+
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// UsageMask is a value of Cryptographic Usage Mask attribute
+//
+// UsageMask is a bit mask of CRYPTO_USAGE_MASK_* values, encoded as Integer.
+type UsageMask uint32
+
+// usageMaskNames lists usage mask bits in the order they're printed
+var usageMaskNames = []struct {
+	bit  UsageMask
+	name string
+}{
+	{CRYPTO_USAGE_MASK_SIGN, "Sign"},
+	{CRYPTO_USAGE_MASK_VERIFY, "Verify"},
+	{CRYPTO_USAGE_MASK_ENCRYPT, "Encrypt"},
+	{CRYPTO_USAGE_MASK_DECRYPT, "Decrypt"},
+	{CRYPTO_USAGE_MASK_WRAP_KEY, "WrapKey"},
+	{CRYPTO_USAGE_MASK_UNWRAP_KEY, "UnwrapKey"},
+	{CRYPTO_USAGE_MASK_EXPORT, "Export"},
+	{CRYPTO_USAGE_MASK_MAC_GENERATE, "MACGenerate"},
+	{CRYPTO_USAGE_MASK_MAC_VERIFY, "MACVerify"},
+	{CRYPTO_USAGE_MASK_DERIVE_KEY, "DeriveKey"},
+	{CRYPTO_USAGE_MASK_CONTENT_COMMITMENT, "ContentCommitment"},
+	{CRYPTO_USAGE_MASK_KEY_AGREEMENT, "KeyAgreement"},
+	{CRYPTO_USAGE_MASK_CERTIFICATE_SIGN, "CertificateSign"},
+	{CRYPTO_USAGE_MASK_CRL_SIGN, "CRLSign"},
+	{CRYPTO_USAGE_MASK_GENERATE_CRYPTOGRAM, "GenerateCryptogram"},
+	{CRYPTO_USAGE_MASK_VALIDATE_CRYPTOGRAM, "ValidateCryptogram"},
+	{CRYPTO_USAGE_MASK_TRANSLATE_ENCRYPT, "TranslateEncrypt"},
+	{CRYPTO_USAGE_MASK_TRANSLATE_DECRYPT, "TranslateDecrypt"},
+	{CRYPTO_USAGE_MASK_TRANSLATE_WRAP, "TranslateWrap"},
+	{CRYPTO_USAGE_MASK_TRANSLATE_UNWRAP, "TranslateUnwrap"},
+}
+
+// Has returns true if all the bits of flags are set in the mask
+func (m UsageMask) Has(flags UsageMask) bool {
+	return m&flags == flags
+}
+
+// Set sets bits of flags in the mask
+func (m *UsageMask) Set(flags UsageMask) {
+	*m |= flags
+}
+
+// Clear clears bits of flags in the mask
+func (m *UsageMask) Clear(flags UsageMask) {
+	*m &^= flags
+}
+
+// String returns names of the bits set in the mask, e.g. "Encrypt|Decrypt|WrapKey"
+//
+// Unknown bits are printed as hex value, empty mask is printed as "0".
+func (m UsageMask) String() string {
+	if m == 0 {
+		return "0"
+	}
+
+	var parts []string
+
+	for _, b := range usageMaskNames {
+		if m.Has(b.bit) {
+			parts = append(parts, b.name)
+			m.Clear(b.bit)
+		}
+	}
+
+	if m != 0 {
+		parts = append(parts, fmt.Sprintf("0x%08x", uint32(m)))
+	}
+
+	return strings.Join(parts, "|")
+}
+
+// parseUsageMask parses names of the mask bits separated with spaces or '|'
+//
+// This is the form used in KMIP XML and JSON encodings (e.g. "Decrypt Encrypt"
+// or "Encrypt|Decrypt"), names
+// might be mixed with hex values, case and underscores are ignored.
+func parseUsageMask(s string) (m UsageMask, err error) {
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == '|' }) {
+		if strings.HasPrefix(part, "0x") {
+			var v uint64
+
+			if v, err = strconv.ParseUint(part[2:], 16, 32); err != nil {
+				return 0, errors.Wrapf(err, "error parsing usage mask value %q", part)
+			}
+
+			m.Set(UsageMask(v))
+
+			continue
+		}
+
+		found := false
+
+		for _, b := range usageMaskNames {
+			if normalizeName(b.name) == normalizeName(part) {
+				m.Set(b.bit)
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return 0, errors.Errorf("unknown usage mask value %q", part)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,47 @@
+package kmip
+
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+// Certificate is a Managed Object that is a digital certificate (e.g. DER-encoded X.509 certificate)
+type Certificate struct {
+	Tag `kmip:"CERTIFICATE"`
+
+	CertificateType  Enum   `kmip:"CERTIFICATE_TYPE,required"`
+	CertificateValue []byte `kmip:"CERTIFICATE_VALUE,required"`
+}
+
+// SecretData is a Managed Cryptographic Object containing a shared secret value
+// that is not a key or certificate (e.g. a password)
+type SecretData struct {
+	Tag `kmip:"SECRET_DATA"`
+
+	SecretDataType Enum     `kmip:"SECRET_DATA_TYPE,required"`
+	KeyBlock       KeyBlock `kmip:"KEY_BLOCK,required"`
+}
+
+// OpaqueObject is a Managed Object that the key management server is possibly
+// not able to interpret
+type OpaqueObject struct {
+	Tag `kmip:"OPAQUE_OBJECT"`
+
+	OpaqueDataType  Enum   `kmip:"OPAQUE_DATA_TYPE,required"`
+	OpaqueDataValue []byte `kmip:"OPAQUE_DATA_VALUE,required"`
+}
+
+// Template is a Managed Object that contains attribute values used to create
+// other objects (deprecated since KMIP 1.3)
+type Template struct {
+	Tag `kmip:"TEMPLATE"`
+
+	Attributes Attributes `kmip:"ATTRIBUTE,required"`
+}
+
+// CertificateRequest is a Managed Object that is a certificate signing request (KMIP 2.0)
+type CertificateRequest struct {
+	Tag `kmip:"CERTIFICATE_REQUEST"`
+
+	CertificateRequestType  Enum   `kmip:"CERTIFICATE_REQUEST_TYPE,required"`
+	CertificateRequestValue []byte `kmip:"CERTIFICATE_REQUEST_VALUE,required"`
+}
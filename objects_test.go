@@ -0,0 +1,130 @@
+package kmip
+
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ObjectsSuite struct {
+	suite.Suite
+}
+
+func (s *ObjectsSuite) keyBlock() KeyBlock {
+	return KeyBlock{
+		FormatType: KEY_FORMAT_RAW,
+		Value: KeyValue{
+			KeyMaterial: []byte{0x01, 0x02, 0x03, 0x04},
+		},
+	}
+}
+
+func (s *ObjectsSuite) requests() []RegisterRequest {
+	return []RegisterRequest{
+		{
+			ObjectType: OBJECT_TYPE_CERTIFICATE,
+			Certificate: Certificate{
+				CertificateType:  CERTIFICATE_TYPE_X_509,
+				CertificateValue: []byte{0x30, 0x82, 0x01, 0x0a},
+			},
+		},
+		{
+			ObjectType: OBJECT_TYPE_SECRET_DATA,
+			SecretData: SecretData{
+				SecretDataType: SECRET_DATA_TYPE_PASSWORD,
+				KeyBlock:       s.keyBlock(),
+			},
+		},
+		{
+			ObjectType: OBJECT_TYPE_OPAQUE_DATA,
+			OpaqueObject: OpaqueObject{
+				OpaqueDataType:  Enum(0x80000001),
+				OpaqueDataValue: []byte("opaque"),
+			},
+		},
+		{
+			ObjectType: OBJECT_TYPE_SPLIT_KEY,
+			SplitKey: SplitKey{
+				SplitKeyParts:     3,
+				KeyPartIdentifier: 1,
+				SplitKeyThreshold: 2,
+				SplitKeyMethod:    SPLIT_KEY_METHOD_POLYNOMIAL_SHARING_PRIME,
+				PrimeFieldSize:    big.NewInt(65521),
+				KeyBlock:          s.keyBlock(),
+			},
+		},
+		{
+			ObjectType: OBJECT_TYPE_TEMPLATE,
+			Template: Template{
+				Attributes: Attributes{
+					{Name: ATTRIBUTE_NAME_CRYPTOGRAPHIC_ALGORITHM, Value: CRYPTO_AES},
+					{Name: ATTRIBUTE_NAME_CRYPTOGRAPHIC_LENGTH, Value: int32(128)},
+				},
+			},
+		},
+		{
+			ObjectType: OBJECT_TYPE_PGP_KEY,
+			PGPKey: PGPKey{
+				PGPKeyVersion: 4,
+				KeyBlock:      s.keyBlock(),
+			},
+		},
+		{
+			ObjectType: OBJECT_TYPE_CERTIFICATE_REQUEST,
+			CertificateRequest: CertificateRequest{
+				CertificateRequestType:  CERTIFICATE_REQUEST_TYPE_PKCS_10,
+				CertificateRequestValue: []byte{0x30, 0x81, 0x9f},
+			},
+		},
+	}
+}
+
+func (s *ObjectsSuite) TestRegisterRequest() {
+	for _, req := range s.requests() {
+		req := req
+
+		s.Run(EnumName(OBJECT_TYPE, req.ObjectType), func() {
+			data, err := Marshal(&req)
+			s.Require().NoError(err)
+
+			var decoded RegisterRequest
+			s.Require().NoError(NewDecoder(bytes.NewReader(data)).Decode(&decoded))
+			s.Assert().Equal(req, decoded)
+		})
+	}
+}
+
+func (s *ObjectsSuite) TestGetResponse() {
+	for _, req := range s.requests() {
+		resp := GetResponse{
+			ObjectType:         req.ObjectType,
+			UniqueIdentifier:   "1",
+			Certificate:        req.Certificate,
+			SecretData:         req.SecretData,
+			OpaqueObject:       req.OpaqueObject,
+			SplitKey:           req.SplitKey,
+			Template:           req.Template,
+			PGPKey:             req.PGPKey,
+			CertificateRequest: req.CertificateRequest,
+		}
+
+		s.Run(EnumName(OBJECT_TYPE, resp.ObjectType), func() {
+			data, err := Marshal(&resp)
+			s.Require().NoError(err)
+
+			var decoded GetResponse
+			s.Require().NoError(NewDecoder(bytes.NewReader(data)).Decode(&decoded))
+			s.Assert().Equal(resp, decoded)
+		})
+	}
+}
+
+func TestObjectsSuite(t *testing.T) {
+	suite.Run(t, new(ObjectsSuite))
+}
@@ -8,6 +8,25 @@ import (
  * License, v. 2.0. If a copy of the MPL was not distributed with this
  * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
 
+func init() {
+	RegisterOperation(OPERATION_CREATE, &CreateRequest{}, &CreateResponse{})
+	RegisterOperation(OPERATION_CREATE_KEY_PAIR, &CreateKeyPairRequest{}, &CreateKeyPairResponse{})
+	RegisterOperation(OPERATION_GET, &GetRequest{}, &GetResponse{})
+	RegisterOperation(OPERATION_GET_ATTRIBUTES, &GetAttributesRequest{}, &GetAttributesResponse{})
+	RegisterOperation(OPERATION_GET_ATTRIBUTE_LIST, &GetAttributeListRequest{}, &GetAttributeListResponse{})
+	RegisterOperation(OPERATION_ACTIVATE, &ActivateRequest{}, &ActivateResponse{})
+	RegisterOperation(OPERATION_REVOKE, &RevokeRequest{}, &RevokeResponse{})
+	RegisterOperation(OPERATION_DESTROY, &DestroyRequest{}, &DestroyResponse{})
+	RegisterOperation(OPERATION_DISCOVER_VERSIONS, &DiscoverVersionsRequest{}, &DiscoverVersionsResponse{})
+	RegisterOperation(OPERATION_QUERY, &QueryRequest{}, &QueryResponse{})
+	RegisterOperation(OPERATION_ENCRYPT, &EncryptRequest{}, &EncryptResponse{})
+	RegisterOperation(OPERATION_DECRYPT, &DecryptRequest{}, &DecryptResponse{})
+	RegisterOperation(OPERATION_SIGN, &SignRequest{}, &SignResponse{})
+	RegisterOperation(OPERATION_REGISTER, &RegisterRequest{}, &RegisterResponse{})
+	RegisterOperation(OPERATION_LOCATE, &LocateRequest{}, &LocateResponse{})
+	RegisterOperation(OPERATION_REKEY, &ReKeyRequest{}, &ReKeyResponse{})
+}
+
 // CreateRequest is a Create Request Payload
 type CreateRequest struct {
 	ObjectType        Enum              `kmip:"OBJECT_TYPE,required"`
@@ -49,10 +68,17 @@ type GetRequest struct {
 type GetResponse struct {
 	ObjectType       Enum   `kmip:"OBJECT_TYPE,required"`
 	UniqueIdentifier string `kmip:"UNIQUE_IDENTIFIER,required"`
-	// Response might contain one of SymmetricKey, Certificate, ...
-	SymmetricKey SymmetricKey `kmip:"SYMMETRIC_KEY"`
-	PrivateKey   PrivateKey   `kmip:"PRIVATE_KEY"`
-	PublicKey    PublicKey    `kmip:"PUBLIC_KEY"`
+	// Response contains one of the objects according to ObjectType
+	Certificate        Certificate        `kmip:"CERTIFICATE"`
+	SymmetricKey       SymmetricKey       `kmip:"SYMMETRIC_KEY"`
+	PublicKey          PublicKey          `kmip:"PUBLIC_KEY"`
+	PrivateKey         PrivateKey         `kmip:"PRIVATE_KEY"`
+	SplitKey           SplitKey           `kmip:"SPLIT_KEY"`
+	Template           Template           `kmip:"TEMPLATE"`
+	SecretData         SecretData         `kmip:"SECRET_DATA"`
+	OpaqueObject       OpaqueObject       `kmip:"OPAQUE_OBJECT"`
+	PGPKey             PGPKey             `kmip:"PGP_KEY"`
+	CertificateRequest CertificateRequest `kmip:"CERTIFICATE_REQUEST"`
 }
 
 // GetAttributesRequest is a Get Attributes Request Payload
@@ -178,6 +204,7 @@ type SignRequest struct {
 	UniqueIdentifier string       `kmip:"UNIQUE_IDENTIFIER"`
 	CryptoParams     CryptoParams `kmip:"CRYPTOGRAPHIC_PARAMETERS"`
 	Data             []byte       `kmip:"DATA"`
+	DigestedData     []byte       `kmip:"DIGESTED_DATA"`
 	CorrelationValue []byte       `kmip:"CORRELATION_VALUE"`
 	InitIndicator    bool         `kmip:"INIT_INDICATOR"`
 	FinalIndicator   bool         `kmip:"FINAL_INDICATOR"`
@@ -194,10 +221,17 @@ type SignResponse struct {
 type RegisterRequest struct {
 	ObjectType        Enum              `kmip:"OBJECT_TYPE,required"`
 	TemplateAttribute TemplateAttribute `kmip:"TEMPLATE_ATTRIBUTE,required"`
-	// Request might contain one of SymmetricKey, Certificate, ...
-	SymmetricKey SymmetricKey `kmip:"SYMMETRIC_KEY"`
-	PrivateKey   PrivateKey   `kmip:"PRIVATE_KEY"`
-	PublicKey    PublicKey    `kmip:"PUBLIC_KEY"`
+	// Request contains one of the objects according to ObjectType
+	Certificate        Certificate        `kmip:"CERTIFICATE"`
+	SymmetricKey       SymmetricKey       `kmip:"SYMMETRIC_KEY"`
+	PublicKey          PublicKey          `kmip:"PUBLIC_KEY"`
+	PrivateKey         PrivateKey         `kmip:"PRIVATE_KEY"`
+	SplitKey           SplitKey           `kmip:"SPLIT_KEY"`
+	Template           Template           `kmip:"TEMPLATE"`
+	SecretData         SecretData         `kmip:"SECRET_DATA"`
+	OpaqueObject       OpaqueObject       `kmip:"OPAQUE_OBJECT"`
+	PGPKey             PGPKey             `kmip:"PGP_KEY"`
+	CertificateRequest CertificateRequest `kmip:"CERTIFICATE_REQUEST"`
 }
 
 // RegisterResponse is a Register Response Payload
@@ -220,10 +254,13 @@ type LocateResponse struct {
 	LocatedItems      int32    `kmip:"LOCATED_ITEMS"`
 	UniqueIdentifiers []string `kmip:"UNIQUE_IDENTIFIER"`
 }
+
+// ReKeyRequest is a Re-key Request Payload
 type ReKeyRequest struct {
 	UniqueIdentifier string `kmip:"UNIQUE_IDENTIFIER,required"`
 }
 
+// ReKeyResponse is a Re-key Response Payload
 type ReKeyResponse struct {
 	UniqueIdentifier string `kmip:"UNIQUE_IDENTIFIER,required"`
 }
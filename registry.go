@@ -0,0 +1,196 @@
+package kmip
+
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Extension ranges reserved by KMIP spec for vendor-specific values
+const (
+	TagExtensionMin Tag = 0x540000
+	TagExtensionMax Tag = 0x54FFFF
+
+	EnumExtensionMin Enum = 0x80000000
+	EnumExtensionMax Enum = 0x8FFFFFFF
+)
+
+// RegisterTag registers vendor extension tag
+//
+// Once registered, name could be used in `kmip` struct tags, and the tag
+// is printed by name in Dump, JSON and XML encodings. Value should be
+// in the extension range 0x540000-0x54FFFF.
+//
+// RegisterTag panics if the tag is not valid or conflicts with already registered one.
+func RegisterTag(name string, value Tag) {
+	if name == "" {
+		panic("kmip: empty tag name")
+	}
+
+	if value < TagExtensionMin || value > TagExtensionMax {
+		panic(fmt.Sprintf("kmip: tag %s value 0x%06x is outside of extension range", name, uint32(value)))
+	}
+
+	if existing, ok := tagMap[name]; ok {
+		if existing == value {
+			return
+		}
+
+		panic(fmt.Sprintf("kmip: tag %s is already registered as 0x%06x", name, uint32(existing)))
+	}
+
+	if existing, ok := tagNames[value]; ok {
+		panic(fmt.Sprintf("kmip: tag 0x%06x is already registered as %s", uint32(value), existing))
+	}
+
+	tagMap[name] = value
+	tagNames[value] = name
+	tagNormalizedMap[normalizeName(name)] = value
+}
+
+// RegisterEnum registers name for the enumeration value in the context of the tag
+//
+// Registered names are used by EnumName, ParseEnum, Dump, JSON and XML encodings.
+// Either tag should be vendor extension tag (see RegisterTag), or value should be
+// in the extension range 0x80000000-0x8FFFFFFF.
+//
+// RegisterEnum panics if the value is not valid or conflicts with already registered one.
+func RegisterEnum(tag Tag, name string, value Enum) {
+	if name == "" {
+		panic("kmip: empty enumeration name")
+	}
+
+	if (tag < TagExtensionMin || tag > TagExtensionMax) && (value < EnumExtensionMin || value > EnumExtensionMax) {
+		panic(fmt.Sprintf("kmip: enumeration %s value 0x%08x for tag %s is outside of extension range", name, uint32(value), tag))
+	}
+
+	names := enumNames[tag]
+
+	if existing, ok := names[value]; ok {
+		if existing == name {
+			return
+		}
+
+		panic(fmt.Sprintf("kmip: enumeration value 0x%08x for tag %s is already registered as %s", uint32(value), tag, existing))
+	}
+
+	for v, existing := range names {
+		if normalizeName(existing) == normalizeName(name) {
+			panic(fmt.Sprintf("kmip: enumeration %s for tag %s is already registered as 0x%08x", name, tag, uint32(v)))
+		}
+	}
+
+	// maps might be shared between tags (e.g. hashing algorithms), so copy before modifying
+	m := make(map[Enum]string, len(names)+1)
+	for v, existing := range names {
+		m[v] = existing
+	}
+
+	m[value] = name
+	enumNames[tag] = m
+}
+
+// operationPayloads holds prototypes of request and response payloads for the operation
+type operationPayloads struct {
+	request, response reflect.Type
+}
+
+var (
+	operationRegistry      = map[Enum]operationPayloads{}
+	attributeRegistry      = map[string]reflect.Type{}
+	credentialTypeRegistry = map[Enum]reflect.Type{}
+	keyMaterialRegistry    = map[Enum]reflect.Type{}
+)
+
+// RegisterOperation registers request and response payload types for the operation
+//
+// req and resp should be pointers to payload structs (e.g. &CreateRequest{}) or nil
+// if the operation doesn't have request or response payload implemented.
+// Registered types are used by RequestBatchItem and ResponseBatchItem
+// to decode payloads. Registering the same operation again replaces previous
+// registration, so built-in payload types could be overridden.
+func RegisterOperation(op Enum, req, resp interface{}) {
+	operationRegistry[op] = operationPayloads{
+		request:  payloadType(req, "operation "+EnumName(OPERATION, op)),
+		response: payloadType(resp, "operation "+EnumName(OPERATION, op)),
+	}
+}
+
+// RegisterAttribute registers type of the attribute value by attribute name
+//
+// proto is a value of the attribute type, e.g. int32(0), Enum(0), "" or
+// pointer to the struct (e.g. &Name{}) for structure attributes. Registered types
+// are used by Attribute to decode values. Registering the same attribute again
+// replaces previous registration.
+func RegisterAttribute(name string, proto interface{}) {
+	if name == "" {
+		panic("kmip: empty attribute name")
+	}
+
+	if proto == nil {
+		panic(fmt.Sprintf("kmip: nil value type for attribute %s", name))
+	}
+
+	typ := reflect.TypeOf(proto)
+	if typ.Kind() == reflect.Struct && typ != typeOfTime && !isCustomType(typ) {
+		panic(fmt.Sprintf("kmip: value type for attribute %s should be pointer to struct, got %s", name, typ))
+	}
+
+	attributeRegistry[name] = typ
+}
+
+// RegisterCredentialType registers type of the credential value by credential type
+//
+// proto should be pointer to the credential struct (e.g. &CredentialUsernamePassword{}).
+// Registered types are used by Authentication to decode credential value. Registering
+// the same credential type again replaces previous registration.
+func RegisterCredentialType(credentialType Enum, proto interface{}) {
+	typ := payloadType(proto, "credential type "+EnumName(CREDENTIAL_TYPE, credentialType))
+	if typ == nil {
+		panic(fmt.Sprintf("kmip: nil value type for credential type %s", EnumName(CREDENTIAL_TYPE, credentialType)))
+	}
+
+	credentialTypeRegistry[credentialType] = typ
+}
+
+// RegisterKeyMaterial registers type of the Key Material structure by key format type
+//
+// proto should be pointer to the key material struct (e.g. &TransparentRSAPrivateKey{}).
+// Registered types are used by KeyBlock to decode structure Key Material, Key Material
+// of formats which are not registered is decoded as TTLV. Registering the same format
+// type again replaces previous registration.
+func RegisterKeyMaterial(formatType Enum, proto interface{}) {
+	typ := payloadType(proto, "key format type "+EnumName(KEY_FORMAT_TYPE, formatType))
+	if typ == nil {
+		panic(fmt.Sprintf("kmip: nil value type for key format type %s", EnumName(KEY_FORMAT_TYPE, formatType)))
+	}
+
+	keyMaterialRegistry[formatType] = typ
+}
+
+// payloadType verifies that proto is nil or pointer to the struct and returns its type
+func payloadType(proto interface{}, what string) reflect.Type {
+	if proto == nil {
+		return nil
+	}
+
+	typ := reflect.TypeOf(proto)
+	if typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("kmip: value type for %s should be pointer to struct, got %s", what, typ))
+	}
+
+	return typ
+}
+
+// newValue builds new value of the registered type: pointer types get
+// newly allocated value, other types get zero value
+func newValue(typ reflect.Type) interface{} {
+	if typ.Kind() == reflect.Ptr {
+		return reflect.New(typ.Elem()).Interface()
+	}
+
+	return reflect.Zero(typ).Interface()
+}
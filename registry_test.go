@@ -0,0 +1,253 @@
+package kmip
+
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	testVendorFoo    Tag = 0x54FF01
+	testVendorBar    Tag = 0x54FF02
+	testVendorUnused Tag = 0x54FF03
+
+	testVendorBarBaz Enum = 0x00000002
+
+	testVendorOperation      Enum = 0x80000001
+	testVendorCredentialType Enum = 0x80000001
+)
+
+func init() {
+	RegisterTag("X_TEST_VENDOR_FOO", testVendorFoo)
+	RegisterTag("X_TEST_VENDOR_BAR", testVendorBar)
+	RegisterEnum(testVendorBar, "BAZ", testVendorBarBaz)
+	RegisterEnum(HASHING_ALGORITHM, "X_TEST_HASH", 0x80000001)
+	RegisterEnum(OPERATION, "X_TEST_OPERATION", testVendorOperation)
+
+	RegisterOperation(testVendorOperation, &testVendorRequest{}, &testVendorResponse{})
+	RegisterAttribute("x-test-vendor-attribute", &testVendorExtension{})
+	RegisterAttribute("x-test-vendor-counter", int64(0))
+	RegisterCredentialType(testVendorCredentialType, &testVendorCredential{})
+}
+
+type testVendorRequest struct {
+	Tag `kmip:"REQUEST_PAYLOAD"`
+
+	Foo string `kmip:"X_TEST_VENDOR_FOO,required"`
+}
+
+type testVendorResponse struct {
+	Tag `kmip:"RESPONSE_PAYLOAD"`
+
+	Bar Enum `kmip:"X_TEST_VENDOR_BAR,required"`
+}
+
+type testVendorCredential struct {
+	Tag `kmip:"CREDENTIAL_VALUE"`
+
+	Foo string `kmip:"X_TEST_VENDOR_FOO,required"`
+}
+
+type testVendorExtension struct {
+	Tag `kmip:"VENDOR_EXTENSION"`
+
+	Foo string `kmip:"X_TEST_VENDOR_FOO"`
+	Bar Enum   `kmip:"X_TEST_VENDOR_BAR"`
+}
+
+type RegistrySuite struct {
+	suite.Suite
+}
+
+func (s *RegistrySuite) TestRegisterTag() {
+	s.Assert().Equal("X_TEST_VENDOR_FOO", testVendorFoo.String())
+	s.Assert().Equal("XTestVendorFoo", tagToText(testVendorFoo))
+	s.Assert().Equal(testVendorFoo, tagNormalizedMap["xtestvendorfoo"])
+
+	// registering the same tag again is no-op
+	s.Assert().NotPanics(func() { RegisterTag("X_TEST_VENDOR_FOO", testVendorFoo) })
+
+	s.Assert().PanicsWithValue("kmip: empty tag name", func() { RegisterTag("", testVendorUnused) })
+	s.Assert().PanicsWithValue("kmip: tag X_TEST_STANDARD value 0x420001 is outside of extension range",
+		func() { RegisterTag("X_TEST_STANDARD", 0x420001) })
+	s.Assert().PanicsWithValue("kmip: tag X_TEST_VENDOR_FOO is already registered as 0x54ff01",
+		func() { RegisterTag("X_TEST_VENDOR_FOO", testVendorUnused) })
+	s.Assert().PanicsWithValue("kmip: tag 0x54ff01 is already registered as X_TEST_VENDOR_FOO",
+		func() { RegisterTag("X_TEST_VENDOR_OTHER", testVendorFoo) })
+}
+
+func (s *RegistrySuite) TestRegisterEnum() {
+	s.Assert().Equal("BAZ", EnumName(testVendorBar, testVendorBarBaz))
+	s.Assert().Equal("X_TEST_HASH", EnumName(HASHING_ALGORITHM, 0x80000001))
+	s.Assert().Equal("SHA256", EnumName(HASHING_ALGORITHM, HASH_SHA256))
+
+	// enumeration names shared between tags are not affected
+	s.Assert().Equal("0x80000001", EnumName(MASK_GENERATOR_HASHING_ALGORITHM, 0x80000001))
+
+	e, err := ParseEnum(testVendorBar, "Baz")
+	s.Require().NoError(err)
+	s.Assert().Equal(testVendorBarBaz, e)
+
+	s.Assert().NotPanics(func() { RegisterEnum(testVendorBar, "BAZ", testVendorBarBaz) })
+
+	s.Assert().PanicsWithValue("kmip: enumeration X_TEST_AES value 0x00000100 for tag CRYPTOGRAPHIC_ALGORITHM is outside of extension range",
+		func() { RegisterEnum(CRYPTOGRAPHIC_ALGORITHM, "X_TEST_AES", 0x100) })
+	s.Assert().PanicsWithValue("kmip: enumeration value 0x00000002 for tag X_TEST_VENDOR_BAR is already registered as BAZ",
+		func() { RegisterEnum(testVendorBar, "QUX", testVendorBarBaz) })
+	s.Assert().PanicsWithValue("kmip: enumeration baz for tag X_TEST_VENDOR_BAR is already registered as 0x00000002",
+		func() { RegisterEnum(testVendorBar, "baz", 3) })
+}
+
+func (s *RegistrySuite) TestStructWithVendorTags() {
+	ext := testVendorExtension{Foo: "foo", Bar: testVendorBarBaz}
+
+	data, err := Marshal(&ext)
+	s.Require().NoError(err)
+	s.Assert().EqualValues(s.parseSpecValue("42 00 9C | 01 | 00 00 00 20 |"+
+		" 54 FF 01 | 07 | 00 00 00 03 | 66 6F 6F 00 00 00 00 00 |"+
+		" 54 FF 02 | 05 | 00 00 00 04 | 00 00 00 02 00 00 00 00"), data)
+
+	var buf bytes.Buffer
+	s.Require().NoError(Dump(&buf, data))
+	s.Assert().Equal("VENDOR_EXTENSION (0x42009c) STRUCTURE [32]\n"+
+		"  X_TEST_VENDOR_FOO (0x54ff01) TEXT_STRING [3]: \"foo\"\n"+
+		"  X_TEST_VENDOR_BAR (0x54ff02) ENUMERATION [4]: BAZ (0x00000002)\n", buf.String())
+
+	var ext2 testVendorExtension
+	s.Require().NoError(NewDecoder(bytes.NewReader(data)).Decode(&ext2))
+	s.Assert().Equal(ext, ext2)
+}
+
+func (s *RegistrySuite) TestMessageExtension() {
+	vendorData, err := Marshal(&testVendorExtension{Foo: "foo", Bar: testVendorBarBaz})
+	s.Require().NoError(err)
+
+	var vendor TTLV
+	s.Require().NoError(NewDecoder(bytes.NewReader(vendorData)).Decode(&vendor))
+
+	ext := MessageExtension{
+		VendorIdentification: "test",
+		CriticalityIndicator: true,
+		VendorExtension:      &vendor,
+	}
+
+	data, err := Marshal(&ext)
+	s.Require().NoError(err)
+
+	var ext2 MessageExtension
+	s.Require().NoError(NewDecoder(bytes.NewReader(data)).Decode(&ext2))
+	s.Assert().Equal(ext, ext2)
+
+	// vendor extension could be decoded further into vendor-specific struct
+	s.Require().NotNil(ext2.VendorExtension)
+	s.Assert().Equal(VENDOR_EXTENSION, ext2.VendorExtension.Tag)
+
+	vendorData, err = Marshal(ext2.VendorExtension)
+	s.Require().NoError(err)
+
+	var vendorExt testVendorExtension
+	s.Require().NoError(NewDecoder(bytes.NewReader(vendorData)).Decode(&vendorExt))
+	s.Assert().Equal("foo", vendorExt.Foo)
+	s.Assert().Equal(testVendorBarBaz, vendorExt.Bar)
+}
+
+func (s *RegistrySuite) TestRegisterOperation() {
+	req := Request{
+		Header: RequestHeader{
+			Version:    ProtocolVersion{Major: 1, Minor: 4},
+			BatchCount: 1,
+			Authentication: Authentication{
+				CredentialType:  testVendorCredentialType,
+				CredentialValue: testVendorCredential{Foo: "secret"},
+			},
+		},
+		BatchItems: []RequestBatchItem{
+			{
+				Operation:      testVendorOperation,
+				RequestPayload: testVendorRequest{Foo: "foo"},
+			},
+		},
+	}
+
+	data, err := Marshal(&req)
+	s.Require().NoError(err)
+
+	var req2 Request
+	s.Require().NoError(NewDecoder(bytes.NewReader(data)).Decode(&req2))
+	s.Assert().Equal(req, req2)
+
+	resp := Response{
+		Header: ResponseHeader{
+			Version:    ProtocolVersion{Major: 1, Minor: 4},
+			TimeStamp:  time.Unix(1500000000, 0),
+			BatchCount: 1,
+		},
+		BatchItems: []ResponseBatchItem{
+			{
+				Operation:       testVendorOperation,
+				ResultStatus:    RESULT_STATUS_SUCCESS,
+				ResponsePayload: &testVendorResponse{Bar: testVendorBarBaz},
+			},
+		},
+	}
+
+	data, err = Marshal(&resp)
+	s.Require().NoError(err)
+
+	var resp2 Response
+	s.Require().NoError(NewDecoder(bytes.NewReader(data)).Decode(&resp2))
+	s.Require().Len(resp2.BatchItems, 1)
+	s.Assert().Equal(testVendorResponse{Bar: testVendorBarBaz}, resp2.BatchItems[0].ResponsePayload)
+
+	bi := RequestBatchItem{Operation: 0x80000002}
+	_, err = bi.BuildFieldValue("RequestPayload")
+	s.Assert().EqualError(err, "unsupported operation: 2147483650")
+
+	s.Assert().PanicsWithValue("kmip: value type for operation X_TEST_OPERATION should be pointer to struct, got kmip.testVendorRequest",
+		func() { RegisterOperation(testVendorOperation, testVendorRequest{}, nil) })
+	s.Assert().PanicsWithValue("kmip: nil value type for credential type 0x80000002",
+		func() { RegisterCredentialType(0x80000002, nil) })
+}
+
+func (s *RegistrySuite) TestRegisterAttribute() {
+	attrs := Attributes{
+		{Name: "x-test-vendor-attribute", Value: testVendorExtension{Foo: "foo"}},
+		{Name: "x-test-vendor-counter", Value: int64(42)},
+	}
+
+	data, err := Marshal(&TemplateAttribute{Attributes: attrs})
+	s.Require().NoError(err)
+
+	var tmpl TemplateAttribute
+	s.Require().NoError(NewDecoder(bytes.NewReader(data)).Decode(&tmpl))
+	s.Assert().Equal(attrs, tmpl.Attributes)
+
+	s.Assert().PanicsWithValue("kmip: value type for attribute x-test-bad should be pointer to struct, got kmip.Name",
+		func() { RegisterAttribute("x-test-bad", Name{}) })
+
+	a := Attribute{Name: "Test Unknown Attribute"}
+	v, err := a.BuildFieldValue("Value")
+	s.Require().NoError(err)
+	s.Assert().Equal(TTLV{}, v)
+}
+
+func (s *RegistrySuite) parseSpecValue(val string) []byte {
+	val = strings.ReplaceAll(strings.ReplaceAll(val, "|", ""), " ", "")
+
+	res, err := hex.DecodeString(val)
+	s.Require().NoError(err)
+
+	return res
+}
+
+func TestRegistrySuite(t *testing.T) {
+	suite.Run(t, new(RegistrySuite))
+}
@@ -47,32 +47,17 @@ type RequestBatchItem struct {
 }
 
 // BuildFieldValue builds value for RequestPayload based on Operation
+//
+// Payload types are looked up in the registry, see RegisterOperation.
 func (bi *RequestBatchItem) BuildFieldValue(name string) (v interface{}, err error) {
-	switch bi.Operation {
-	case OPERATION_CREATE:
-		v = &CreateRequest{}
-	case OPERATION_GET:
-		v = &GetRequest{}
-	case OPERATION_GET_ATTRIBUTES:
-		v = &GetAttributesRequest{}
-	case OPERATION_GET_ATTRIBUTE_LIST:
-		v = &GetAttributeListRequest{}
-	case OPERATION_DESTROY:
-		v = &DestroyRequest{}
-	case OPERATION_DISCOVER_VERSIONS:
-		v = &DiscoverVersionsRequest{}
-	case OPERATION_REGISTER:
-		v = &RegisterRequest{}
-	case OPERATION_ACTIVATE:
-		v = &ActivateRequest{}
-	case OPERATION_LOCATE:
-		v = &LocateRequest{}
-	case OPERATION_REVOKE:
-		v = &RevokeRequest{}
-	default:
+	payloads := operationRegistry[bi.Operation]
+	if payloads.request == nil {
 		err = errors.Errorf("unsupported operation: %v", bi.Operation)
+		return
 	}
 
+	v = newValue(payloads.request)
+
 	return
 }
 
@@ -85,12 +70,15 @@ type ProtocolVersion struct {
 }
 
 // MessageExtension is a Message Extension structure in a Batch Item
+//
+// VendorExtension holds vendor-specific content as generic TTLV; vendor
+// tags could be registered with RegisterTag to get them decoded by name.
 type MessageExtension struct {
 	Tag `kmip:"MESSAGE_EXTENSION"`
 
-	VendorIdentification string      `kmip:"VENDOR_IDENTIFICATION,required"`
-	CriticalityIndicator bool        `kmip:"CRITICALITY_INDICATOR,required"`
-	VendorExtension      interface{} `kmip:"-,skip"`
+	VendorIdentification string `kmip:"VENDOR_IDENTIFICATION,required"`
+	CriticalityIndicator bool   `kmip:"CRITICALITY_INDICATOR,required"`
+	VendorExtension      *TTLV  `kmip:"VENDOR_EXTENSION"`
 }
 
 // RevocationReason is a Revocation Reason structure
@@ -98,5 +86,5 @@ type RevocationReason struct {
 	Tag `kmip:"REVOCATION_REASON"`
 
 	RevocationReasonCode Enum   `kmip:"REVOCATION_REASON_CODE"`
-	RevocationMessage    string `kmip:"REVOCATION_REASON"`
+	RevocationMessage    string `kmip:"REVOCATION_MESSAGE"`
 }
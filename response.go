@@ -44,42 +44,17 @@ type ResponseBatchItem struct {
 }
 
 // BuildFieldValue builds value for ResponsePayload based on Operation
+//
+// Payload types are looked up in the registry, see RegisterOperation.
 func (bi *ResponseBatchItem) BuildFieldValue(name string) (v interface{}, err error) {
-	switch bi.Operation {
-	case OPERATION_CREATE:
-		v = &CreateResponse{}
-	case OPERATION_CREATE_KEY_PAIR:
-		v = &CreateKeyPairResponse{}
-	case OPERATION_GET:
-		v = &GetResponse{}
-	case OPERATION_GET_ATTRIBUTES:
-		v = &GetAttributesResponse{}
-	case OPERATION_GET_ATTRIBUTE_LIST:
-		v = &GetAttributeListResponse{}
-	case OPERATION_ACTIVATE:
-		v = &ActivateResponse{}
-	case OPERATION_REVOKE:
-		v = &RevokeResponse{}
-	case OPERATION_DESTROY:
-		v = &DestroyResponse{}
-	case OPERATION_DISCOVER_VERSIONS:
-		v = &DiscoverVersionsResponse{}
-	case OPERATION_ENCRYPT:
-		v = &EncryptResponse{}
-	case OPERATION_DECRYPT:
-		v = &DecryptResponse{}
-	case OPERATION_SIGN:
-		v = &SignResponse{}
-	case OPERATION_REGISTER:
-		v = &RegisterResponse{}
-	case OPERATION_LOCATE:
-		v = &LocateResponse{}
-	case OPERATION_REKEY:
-		v = &ReKeyResponse{}
-	default:
+	payloads := operationRegistry[bi.Operation]
+	if payloads.response == nil {
 		err = errors.Errorf("unsupported operation: %v", bi.Operation)
+		return
 	}
 
+	v = newValue(payloads.response)
+
 	return
 }
 
@@ -41,6 +41,15 @@ type Server struct {
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
 
+	// Limits for the incoming request messages
+	//
+	// If not set, defaults to DefaultMaxMessageSize, DefaultMaxDepth
+	// and DefaultMaxItems. Requests exceeding the limits are rejected
+	// with RESULT_REASON_RESPONSE_TOO_LARGE, and connection is closed.
+	MaxMessageSize int
+	MaxDepth       int
+	MaxItems       int
+
 	// SessionAuthHandler is called after TLS handshake
 	//
 	// This handler might additionally verify client TLS cert or perform
@@ -115,6 +124,18 @@ func (s *Server) Serve(l net.Listener, initializedCh chan struct{}) error {
 		s.SupportedVersions = append([]ProtocolVersion(nil), DefaultSupportedVersions...)
 	}
 
+	if s.MaxMessageSize == 0 {
+		s.MaxMessageSize = DefaultMaxMessageSize
+	}
+
+	if s.MaxDepth == 0 {
+		s.MaxDepth = DefaultMaxDepth
+	}
+
+	if s.MaxItems == 0 {
+		s.MaxItems = DefaultMaxItems
+	}
+
 	if s.handlers == nil {
 		s.initHandlers()
 	}
@@ -247,6 +268,7 @@ func (s *Server) serve(conn net.Conn, session string) {
 
 	s.mu.Lock()
 	sessionAuthHandler := s.SessionAuthHandler
+	maxMessageSize, maxDepth, maxItems := s.MaxMessageSize, s.MaxDepth, s.MaxItems
 	s.mu.Unlock()
 
 	if sessionAuthHandler != nil {
@@ -260,6 +282,10 @@ func (s *Server) serve(conn net.Conn, session string) {
 	}
 
 	d := NewDecoder(conn)
+	d.MaxMessageSize = maxMessageSize
+	d.MaxDepth = maxDepth
+	d.MaxItems = maxItems
+
 	e := NewEncoder(conn)
 
 	for {
@@ -276,6 +302,20 @@ func (s *Server) serve(conn net.Conn, session string) {
 
 		if err != nil {
 			s.Log.Printf("[ERROR] [%s] Error decoding KMIP message: %s", session, err)
+
+			var protoErr Error
+			if errors.As(err, &protoErr) {
+				// message was rejected (malformed or exceeding the limits), report that back to the client,
+				// but the rest of the message can't be skipped reliably, so connection is closed anyway
+				if s.WriteTimeout != 0 {
+					_ = conn.SetWriteDeadline(time.Now().Add(s.WriteTimeout))
+				}
+
+				if err = e.Encode(s.errorResponse(req, protoErr)); err != nil {
+					s.Log.Printf("[ERROR] [%s] Error encoding KMIP response: %s", session, err)
+				}
+			}
+
 			break
 		}
 
@@ -365,6 +405,33 @@ func (s *Server) handleBatch(session *SessionContext, req *Request) (resp *Respo
 	return
 }
 
+// errorResponse builds response for the request which failed to be decoded
+func (s *Server) errorResponse(req *Request, err Error) *Response {
+	version := req.Header.Version
+	if version.Major == 0 {
+		version = s.SupportedVersions[0]
+	}
+
+	item := ResponseBatchItem{
+		ResultStatus:  RESULT_STATUS_OPERATION_FAILED,
+		ResultReason:  err.ResultReason(),
+		ResultMessage: err.Error(),
+	}
+
+	if len(req.BatchItems) > 0 {
+		item.Operation = req.BatchItems[0].Operation
+	}
+
+	return &Response{
+		Header: ResponseHeader{
+			Version:    version,
+			TimeStamp:  time.Now(),
+			BatchCount: 1,
+		},
+		BatchItems: []ResponseBatchItem{item},
+	}
+}
+
 func (s *Server) handleWrapped(request *RequestContext, item *RequestBatchItem) (resp interface{}, err error) {
 	defer func() {
 		if p := recover(); p != nil {
@@ -416,6 +483,13 @@ func (s *Server) handleDiscoverVersions(req *RequestContext, item *RequestBatchI
 	return
 }
 
+// Default limits for the incoming request messages
+const (
+	DefaultMaxMessageSize = 1024 * 1024
+	DefaultMaxDepth       = 32
+	DefaultMaxItems       = 10000
+)
+
 // DefaultSupportedVersions is a default list of supported KMIP versions
 var DefaultSupportedVersions = []ProtocolVersion{
 	{Major: 1, Minor: 4},
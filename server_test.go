@@ -6,9 +6,18 @@ package kmip
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"log"
+	"math/big"
 	"net"
 	"os"
 	"testing"
@@ -86,6 +95,8 @@ func (s *ServerSuite) TearDownTest() {
 	// reset server state
 	s.server.mu.Lock()
 	s.server.SessionAuthHandler = nil
+	s.server.MaxMessageSize = DefaultMaxMessageSize
+	s.server.MaxItems = DefaultMaxItems
 	s.server.initHandlers()
 	s.server.mu.Unlock()
 }
@@ -238,6 +249,417 @@ func (s *ServerSuite) TestOperationNotSupported() {
 	s.Require().Equal(errors.Cause(err).(Error).ResultReason(), RESULT_REASON_OPERATION_NOT_SUPPORTED)
 }
 
+func (s *ServerSuite) TestOperationRoundTrip() {
+	attrs := Attributes{
+		{Name: ATTRIBUTE_NAME_CRYPTOGRAPHIC_ALGORITHM, Value: CRYPTO_AES},
+		{Name: ATTRIBUTE_NAME_CRYPTOGRAPHIC_LENGTH, Value: int32(256)},
+	}
+
+	key := SymmetricKey{
+		KeyBlock: KeyBlock{
+			FormatType: KEY_FORMAT_RAW,
+			Value: KeyValue{
+				KeyMaterial: []byte{0x01, 0x02, 0x03, 0x04},
+			},
+			CryptographicAlgorithm: CRYPTO_AES,
+			CryptographicLength:    32,
+		},
+	}
+
+	params := CryptoParams{
+		BlockCipherMode:        BLOCK_MODE_GCM,
+		CryptographicAlgorithm: CRYPTO_AES,
+		TagLength:              16,
+	}
+
+	for _, tc := range []struct {
+		operation Enum
+		req       interface{}
+		resp      interface{}
+	}{
+		{
+			operation: OPERATION_CREATE,
+			req:       CreateRequest{ObjectType: OBJECT_TYPE_SYMMETRIC_KEY, TemplateAttribute: TemplateAttribute{Attributes: attrs}},
+			resp:      CreateResponse{ObjectType: OBJECT_TYPE_SYMMETRIC_KEY, UniqueIdentifier: "1"},
+		},
+		{
+			operation: OPERATION_CREATE_KEY_PAIR,
+			req: CreateKeyPairRequest{
+				CommonTemplateAttribute:     TemplateAttribute{Attributes: Attributes{{Name: ATTRIBUTE_NAME_CRYPTOGRAPHIC_ALGORITHM, Value: CRYPTO_RSA}}},
+				PrivateKeyTemplateAttribute: TemplateAttribute{Attributes: Attributes{{Name: ATTRIBUTE_NAME_CRYPTOGRAPHIC_LENGTH, Value: int32(2048)}}},
+			},
+			resp: CreateKeyPairResponse{PrivateKeyUniqueIdentifier: "2", PublicKeyUniqueIdentifier: "3"},
+		},
+		{
+			operation: OPERATION_GET,
+			req:       GetRequest{UniqueIdentifier: "1", KeyFormatType: KEY_FORMAT_RAW},
+			resp:      GetResponse{ObjectType: OBJECT_TYPE_SYMMETRIC_KEY, UniqueIdentifier: "1", SymmetricKey: key},
+		},
+		{
+			operation: OPERATION_GET_ATTRIBUTES,
+			req:       GetAttributesRequest{UniqueIdentifier: "1", AttributeNames: []string{ATTRIBUTE_NAME_CRYPTOGRAPHIC_ALGORITHM}},
+			resp:      GetAttributesResponse{UniqueIdentifier: "1", Attributes: attrs},
+		},
+		{
+			operation: OPERATION_GET_ATTRIBUTE_LIST,
+			req:       GetAttributeListRequest{UniqueIdentifier: "1"},
+			resp:      GetAttributeListResponse{UniqueIdentifier: "1", AttributeNames: []string{ATTRIBUTE_NAME_CRYPTOGRAPHIC_ALGORITHM, ATTRIBUTE_NAME_CRYPTOGRAPHIC_LENGTH}},
+		},
+		{
+			operation: OPERATION_ACTIVATE,
+			req:       ActivateRequest{UniqueIdentifier: "1"},
+			resp:      ActivateResponse{UniqueIdentifier: "1"},
+		},
+		{
+			operation: OPERATION_REVOKE,
+			req: RevokeRequest{
+				UniqueIdentifier: "1",
+				RevocationReason: RevocationReason{RevocationReasonCode: REVOCATION_REASON_KEY_COMPROMISE, RevocationMessage: "leaked"},
+				CompromiseDate:   time.Unix(1500000000, 0),
+			},
+			resp: RevokeResponse{UniqueIdentifier: "1"},
+		},
+		{
+			operation: OPERATION_DESTROY,
+			req:       DestroyRequest{UniqueIdentifier: "1"},
+			resp:      DestroyResponse{UniqueIdentifier: "1"},
+		},
+		{
+			operation: OPERATION_DISCOVER_VERSIONS,
+			req:       DiscoverVersionsRequest{ProtocolVersions: []ProtocolVersion{{Major: 1, Minor: 4}}},
+			resp:      DiscoverVersionsResponse{ProtocolVersions: []ProtocolVersion{{Major: 1, Minor: 4}}},
+		},
+		{
+			operation: OPERATION_QUERY,
+			req:       QueryRequest{QueryFunctions: []Enum{1, 2}},
+			resp:      QueryResponse{Operations: []Enum{OPERATION_ENCRYPT, OPERATION_DECRYPT}, ObjectTypes: []Enum{OBJECT_TYPE_SYMMETRIC_KEY}},
+		},
+		{
+			operation: OPERATION_ENCRYPT,
+			req:       EncryptRequest{UniqueIdentifier: "1", CryptoParams: params, Data: []byte("hello"), AdditionalData: []byte("aad")},
+			resp:      EncryptResponse{UniqueIdentifier: "1", Data: []byte("olleh"), IVCounterNonce: []byte("nonce"), AuthTag: []byte("tag")},
+		},
+		{
+			operation: OPERATION_DECRYPT,
+			req:       DecryptRequest{UniqueIdentifier: "1", CryptoParams: params, Data: []byte("olleh"), IVCounterNonce: []byte("nonce"), AuthTag: []byte("tag")},
+			resp:      DecryptResponse{UniqueIdentifier: "1", Data: []byte("hello")},
+		},
+		{
+			operation: OPERATION_SIGN,
+			req:       SignRequest{UniqueIdentifier: "2", CryptoParams: CryptoParams{HashingAlgorithm: HASH_SHA256, CryptographicAlgorithm: CRYPTO_RSA}, Data: []byte("hello")},
+			resp:      SignResponse{UniqueIdentifier: "2", SignatureData: []byte("signature")},
+		},
+		{
+			operation: OPERATION_REGISTER,
+			req:       RegisterRequest{ObjectType: OBJECT_TYPE_SYMMETRIC_KEY, TemplateAttribute: TemplateAttribute{Attributes: attrs}, SymmetricKey: key},
+			resp:      RegisterResponse{UniqueIdentifier: "4"},
+		},
+		{
+			operation: OPERATION_LOCATE,
+			req:       LocateRequest{MaximumItems: 10, Attributes: attrs},
+			resp:      LocateResponse{LocatedItems: 2, UniqueIdentifiers: []string{"1", "4"}},
+		},
+		{
+			operation: OPERATION_REKEY,
+			req:       ReKeyRequest{UniqueIdentifier: "1"},
+			resp:      ReKeyResponse{UniqueIdentifier: "5"},
+		},
+	} {
+		tc := tc
+		payloadCh := make(chan interface{}, 1)
+
+		s.server.Handle(tc.operation, func(req *RequestContext, item *RequestBatchItem) (interface{}, error) {
+			payloadCh <- item.RequestPayload
+
+			return tc.resp, nil
+		})
+
+		s.Require().NoError(s.client.Connect())
+
+		resp, err := s.client.Send(tc.operation, tc.req)
+		s.Require().NoError(err, EnumName(OPERATION, tc.operation))
+		s.Assert().Equal(tc.req, <-payloadCh, EnumName(OPERATION, tc.operation))
+		s.Assert().Equal(tc.resp, resp, EnumName(OPERATION, tc.operation))
+
+		s.Require().NoError(s.client.Close())
+	}
+}
+
+// handleKey implements operations required by Signer with the private key priv
+func (s *ServerSuite) handleKey(priv crypto.Signer) {
+	s.server.Handle(OPERATION_GET_ATTRIBUTES, func(req *RequestContext, item *RequestBatchItem) (interface{}, error) {
+		return GetAttributesResponse{
+			UniqueIdentifier: "priv",
+			Attributes: Attributes{
+				{Name: ATTRIBUTE_NAME_LINK, Value: Link{LinkType: LINK_TYPE_PUBLIC_KEY, LinkedObjectIdentifier: "pub"}},
+			},
+		}, nil
+	})
+
+	s.server.Handle(OPERATION_GET, func(req *RequestContext, item *RequestBatchItem) (interface{}, error) {
+		if item.RequestPayload.(GetRequest).UniqueIdentifier != "pub" {
+			return nil, errors.New("wrong unique identifier")
+		}
+
+		pub, err := NewPublicKey(priv.Public(), KEY_FORMAT_X_509)
+		if err != nil {
+			return nil, err
+		}
+
+		return GetResponse{ObjectType: OBJECT_TYPE_PUBLIC_KEY, UniqueIdentifier: "pub", PublicKey: pub}, nil
+	})
+
+	hashByEnum := func(alg Enum) crypto.Hash {
+		for h, a := range hashingAlgorithms {
+			if a == alg {
+				return h
+			}
+		}
+
+		return 0
+	}
+
+	s.server.Handle(OPERATION_SIGN, func(req *RequestContext, item *RequestBatchItem) (interface{}, error) {
+		payload := item.RequestPayload.(SignRequest)
+		params := payload.CryptoParams
+
+		var opts crypto.SignerOpts = hashByEnum(params.HashingAlgorithm)
+
+		if params.PaddingMethod == PADDING_METHOD_PSS {
+			opts = &rsa.PSSOptions{Hash: opts.HashFunc(), SaltLength: int(params.SaltLength)}
+		}
+
+		data := payload.DigestedData
+		if params.CryptographicAlgorithm == CRYPTO_ED25519 {
+			data = payload.Data
+		}
+
+		signature, err := priv.Sign(rand.Reader, data, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		return SignResponse{UniqueIdentifier: "priv", SignatureData: signature}, nil
+	})
+
+	s.server.Handle(OPERATION_DECRYPT, func(req *RequestContext, item *RequestBatchItem) (interface{}, error) {
+		payload := item.RequestPayload.(DecryptRequest)
+		params := payload.CryptoParams
+
+		var opts crypto.DecrypterOpts
+
+		if params.PaddingMethod == PADDING_METHOD_OAEP {
+			opts = &rsa.OAEPOptions{Hash: hashByEnum(params.HashingAlgorithm), Label: params.PSource}
+		}
+
+		data, err := priv.(crypto.Decrypter).Decrypt(rand.Reader, payload.Data, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		return DecryptResponse{UniqueIdentifier: "priv", Data: data}, nil
+	})
+}
+
+func (s *ServerSuite) TestSignerRSA() {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	s.Require().NoError(err)
+
+	s.handleKey(key)
+
+	s.Require().NoError(s.client.Connect())
+
+	signer, err := NewSigner(&s.client, "priv")
+	s.Require().NoError(err)
+	s.Assert().True(key.PublicKey.Equal(signer.Public()))
+
+	digest := sha256.Sum256([]byte("hello"))
+
+	signature, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	s.Require().NoError(err)
+	s.Assert().NoError(rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature))
+
+	pssOpts := &rsa.PSSOptions{Hash: crypto.SHA256, SaltLength: rsa.PSSSaltLengthEqualsHash}
+
+	signature, err = signer.Sign(rand.Reader, digest[:], pssOpts)
+	s.Require().NoError(err)
+	s.Assert().NoError(rsa.VerifyPSS(&key.PublicKey, crypto.SHA256, digest[:], signature, pssOpts))
+
+	_, err = signer.Sign(rand.Reader, digest[:], crypto.SHA512)
+	s.Assert().EqualError(err, "digest length 32 doesn't match hash function SHA-512")
+
+	// TLS 1.1 and earlier
+	_, err = signer.Sign(rand.Reader, make([]byte, 36), crypto.MD5SHA1)
+	s.Assert().EqualError(err, "unsupported hash function MD5+SHA1")
+
+	ciphertext, err := rsa.EncryptPKCS1v15(rand.Reader, &key.PublicKey, []byte("secret"))
+	s.Require().NoError(err)
+
+	plaintext, err := signer.Decrypt(rand.Reader, ciphertext, nil)
+	s.Require().NoError(err)
+	s.Assert().Equal([]byte("secret"), plaintext)
+
+	ciphertext, err = rsa.EncryptOAEP(sha256.New(), rand.Reader, &key.PublicKey, []byte("secret"), []byte("label"))
+	s.Require().NoError(err)
+
+	plaintext, err = signer.Decrypt(rand.Reader, ciphertext, &rsa.OAEPOptions{Hash: crypto.SHA256, Label: []byte("label")})
+	s.Require().NoError(err)
+	s.Assert().Equal([]byte("secret"), plaintext)
+}
+
+func (s *ServerSuite) TestSignerECDSA() {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+
+	s.handleKey(key)
+
+	s.Require().NoError(s.client.Connect())
+
+	signer, err := NewSigner(&s.client, "priv")
+	s.Require().NoError(err)
+	s.Assert().True(key.PublicKey.Equal(signer.Public()))
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "kmip"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, signer.Public(), signer)
+	s.Require().NoError(err)
+
+	cert, err := x509.ParseCertificate(der)
+	s.Require().NoError(err)
+	s.Assert().NoError(cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature))
+
+	_, err = signer.Decrypt(rand.Reader, []byte("secret"), nil)
+	s.Assert().EqualError(err, "decryption is not supported for public key type *ecdsa.PublicKey")
+}
+
+func (s *ServerSuite) TestSignerEd25519() {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	s.Require().NoError(err)
+
+	s.handleKey(key)
+
+	s.Require().NoError(s.client.Connect())
+
+	signer, err := NewSigner(&s.client, "priv")
+	s.Require().NoError(err)
+
+	signature, err := signer.Sign(rand.Reader, []byte("hello"), crypto.Hash(0))
+	s.Require().NoError(err)
+	s.Assert().True(ed25519.Verify(key.Public().(ed25519.PublicKey), []byte("hello"), signature))
+}
+
+func (s *ServerSuite) TestSignerNoLink() {
+	s.server.Handle(OPERATION_GET_ATTRIBUTES, func(req *RequestContext, item *RequestBatchItem) (interface{}, error) {
+		return GetAttributesResponse{UniqueIdentifier: "priv"}, nil
+	})
+
+	s.Require().NoError(s.client.Connect())
+
+	_, err := NewSigner(&s.client, "priv")
+	s.Assert().EqualError(err, "private key priv is not linked to public key")
+}
+
+// rawRoundTrip sends message to the server bypassing the Client and decodes the response
+func (s *ServerSuite) rawRoundTrip(message []byte) (resp Response) {
+	conn, err := tls.Dial("tcp", s.client.Endpoint, s.client.TLSConfig)
+	s.Require().NoError(err)
+
+	defer conn.Close() //nolint:errcheck
+
+	_, err = conn.Write(message)
+	s.Require().NoError(err)
+
+	s.Require().NoError(NewDecoder(conn).Decode(&resp))
+
+	return
+}
+
+// discoverVersionsMessage encodes Discover Versions request
+func (s *ServerSuite) discoverVersionsMessage(versions []ProtocolVersion) []byte {
+	message, err := Marshal(&Request{
+		Header: RequestHeader{
+			Version:    DefaultSupportedVersions[0],
+			BatchCount: 1,
+		},
+		BatchItems: []RequestBatchItem{
+			{
+				Operation:      OPERATION_DISCOVER_VERSIONS,
+				RequestPayload: DiscoverVersionsRequest{ProtocolVersions: versions},
+			},
+		},
+	})
+	s.Require().NoError(err)
+
+	return message
+}
+
+func (s *ServerSuite) TestRequestTooLarge() {
+	s.server.mu.Lock()
+	s.server.MaxMessageSize = 64
+	s.server.mu.Unlock()
+
+	resp := s.rawRoundTrip(s.discoverVersionsMessage(nil))
+
+	s.Require().Len(resp.BatchItems, 1)
+	s.Assert().Equal(RESULT_STATUS_OPERATION_FAILED, resp.BatchItems[0].ResultStatus)
+	s.Assert().Equal(RESULT_REASON_RESPONSE_TOO_LARGE, resp.BatchItems[0].ResultReason)
+	s.Assert().Equal("error decoding RequestMessage at offset 0: item with length 96 ends at offset 104, exceeding maximum message size 64", resp.BatchItems[0].ResultMessage)
+}
+
+func (s *ServerSuite) TestRequestTooManyItems() {
+	s.server.mu.Lock()
+	s.server.MaxItems = 8
+	s.server.mu.Unlock()
+
+	resp := s.rawRoundTrip(s.discoverVersionsMessage(DefaultSupportedVersions))
+
+	s.Require().Len(resp.BatchItems, 1)
+	s.Assert().Equal(RESULT_STATUS_OPERATION_FAILED, resp.BatchItems[0].ResultStatus)
+	s.Assert().Equal(RESULT_REASON_RESPONSE_TOO_LARGE, resp.BatchItems[0].ResultReason)
+	s.Assert().Equal("error decoding RequestMessage/BatchItem[0]/RequestPayload at offset 96: message exceeds maximum number of items 8", resp.BatchItems[0].ResultMessage)
+}
+
+func (s *ServerSuite) TestClientRequestFailed() {
+	s.server.mu.Lock()
+	s.server.MaxMessageSize = 64
+	s.server.mu.Unlock()
+
+	s.Require().NoError(s.client.Connect())
+
+	// server fails to decode the request, so the response doesn't carry the operation
+	_, err := s.client.DiscoverVersions(nil)
+	s.Require().EqualError(errors.Cause(err), "error decoding RequestMessage at offset 0: item with length 96 ends at offset 104, exceeding maximum message size 64")
+	s.Require().Equal(errors.Cause(err).(Error).ResultReason(), RESULT_REASON_RESPONSE_TOO_LARGE)
+}
+
+func (s *ServerSuite) TestRequestMalformed() {
+	conn, err := tls.Dial("tcp", s.client.Endpoint, s.client.TLSConfig)
+	s.Require().NoError(err)
+
+	defer conn.Close() //nolint:errcheck
+
+	// Operation is encoded as Integer instead of Enumeration
+	message := append([]byte(nil), messageGet...)
+	message[83] = byte(INTEGER)
+
+	_, err = conn.Write(message)
+	s.Require().NoError(err)
+
+	var resp Response
+	s.Require().NoError(NewDecoder(conn).Decode(&resp))
+
+	s.Require().Len(resp.BatchItems, 1)
+	s.Assert().Equal(ProtocolVersion{Major: 1, Minor: 1}, resp.Header.Version)
+	s.Assert().Equal(RESULT_STATUS_OPERATION_FAILED, resp.BatchItems[0].ResultStatus)
+	s.Assert().Equal(RESULT_REASON_INVALID_FIELD, resp.BatchItems[0].ResultReason)
+	s.Assert().Equal("error decoding RequestMessage/BatchItem[0]/Operation at offset 80: expecting type 5, but 2 was encountered", resp.BatchItems[0].ResultMessage)
+}
+
 func TestServerSuite(t *testing.T) {
 	suite.Run(t, new(ServerSuite))
 }
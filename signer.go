@@ -0,0 +1,247 @@
+package kmip
+
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"io"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// hashingAlgorithms maps Go hash functions to KMIP Hashing Algorithms
+var hashingAlgorithms = map[crypto.Hash]Enum{
+	crypto.MD5:        HASH_MD5,
+	crypto.SHA1:       HASH_SHA1,
+	crypto.SHA224:     HASH_SHA224,
+	crypto.SHA256:     HASH_SHA256,
+	crypto.SHA384:     HASH_SHA384,
+	crypto.SHA512:     HASH_SHA512,
+	crypto.RIPEMD160:  HASH_RIPEMD_160,
+	crypto.SHA512_224: HASH_SHA512_224,
+	crypto.SHA512_256: HASH_SHA512_256,
+	crypto.SHA3_224:   HASH_SHA3_224,
+	crypto.SHA3_256:   HASH_SHA3_256,
+	crypto.SHA3_384:   HASH_SHA3_384,
+	crypto.SHA3_512:   HASH_SHA3_512,
+}
+
+func hashingAlgorithm(h crypto.Hash) (Enum, error) {
+	alg, ok := hashingAlgorithms[h]
+	if !ok {
+		return 0, errors.Errorf("unsupported hash function %v", h)
+	}
+
+	return alg, nil
+}
+
+// Signer implements crypto.Signer and crypto.Decrypter with the private key
+// held by the KMIP server
+//
+// Signatures and decryption are performed by the server with Sign and Decrypt
+// operations, so the private key never leaves the server. Signer could be used
+// as tls.Certificate.PrivateKey or passed to x509.CreateCertificate.
+//
+// Signer methods are safe for concurrent use, as Signer serializes its own requests.
+// Client is not safe for concurrent use, so it shouldn't be used by anything else
+// (including other Signers) while Signer is in use.
+type Signer struct {
+	mu sync.Mutex
+
+	client *Client
+	uid    string
+	public crypto.PublicKey
+}
+
+// NewSigner builds Signer for the private key with unique identifier uid
+//
+// Client should be connected to the server. Public key is retrieved with Get operation
+// on the object linked to the private key with LINK_TYPE_PUBLIC_KEY link. Supported
+// public keys are RSA, ECDSA and Ed25519 keys (see PublicKey.CryptoPublicKey).
+func NewSigner(client *Client, uid string) (*Signer, error) {
+	s := &Signer{
+		client: client,
+		uid:    uid,
+	}
+
+	resp, err := client.Send(OPERATION_GET_ATTRIBUTES, GetAttributesRequest{
+		UniqueIdentifier: uid,
+		AttributeNames:   []string{ATTRIBUTE_NAME_LINK},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting private key links")
+	}
+
+	var publicUID string
+
+	for _, v := range resp.(GetAttributesResponse).Attributes.GetAll(ATTRIBUTE_NAME_LINK) {
+		var link Link
+
+		switch l := v.(type) {
+		case Link:
+			link = l
+		case *Link:
+			link = *l
+		default:
+			continue
+		}
+
+		if link.LinkType == LINK_TYPE_PUBLIC_KEY {
+			publicUID = link.LinkedObjectIdentifier
+
+			break
+		}
+	}
+
+	if publicUID == "" {
+		return nil, errors.Errorf("private key %s is not linked to public key", uid)
+	}
+
+	resp, err = client.Send(OPERATION_GET, GetRequest{
+		UniqueIdentifier: publicUID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting public key")
+	}
+
+	if s.public, err = resp.(GetResponse).PublicKey.CryptoPublicKey(); err != nil {
+		return nil, errors.Wrap(err, "error converting public key")
+	}
+
+	return s, nil
+}
+
+// Public implements crypto.Signer interface
+func (s *Signer) Public() crypto.PublicKey {
+	return s.public
+}
+
+// Sign implements crypto.Signer interface
+//
+// RSA keys use PKCS#1 v1.5 padding unless opts is *rsa.PSSOptions. For RSA and ECDSA
+// keys digest is sent to the server as Digested Data, for Ed25519 keys digest is the
+// message itself (opts.HashFunc() should be zero).
+//
+// KMIP doesn't define hashing algorithm for crypto.MD5SHA1, so signing for TLS 1.1
+// and earlier with RSA keys is not supported.
+func (s *Signer) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) (signature []byte, err error) {
+	req := SignRequest{
+		UniqueIdentifier: s.uid,
+	}
+
+	hash := opts.HashFunc()
+	digested := true
+
+	switch s.public.(type) {
+	case *rsa.PublicKey:
+		req.CryptoParams.CryptographicAlgorithm = CRYPTO_RSA
+		req.CryptoParams.PaddingMethod = PADDING_METHOD_PKCS_1_V1_5
+
+		if pssOpts, ok := opts.(*rsa.PSSOptions); ok {
+			req.CryptoParams.PaddingMethod = PADDING_METHOD_PSS
+
+			switch {
+			case pssOpts.SaltLength == rsa.PSSSaltLengthEqualsHash:
+				req.CryptoParams.SaltLength = int32(hash.Size())
+			case pssOpts.SaltLength > 0:
+				req.CryptoParams.SaltLength = int32(pssOpts.SaltLength)
+			}
+		}
+	case *ecdsa.PublicKey:
+		req.CryptoParams.CryptographicAlgorithm = CRYPTO_ECDSA
+	case ed25519.PublicKey:
+		if hash != 0 {
+			err = errors.New("ed25519 keys sign the message itself, hash function should be zero")
+			return
+		}
+
+		req.CryptoParams.CryptographicAlgorithm = CRYPTO_ED25519
+		req.Data = digest
+		digested = false
+	default:
+		err = errors.Errorf("unsupported public key type %T", s.public)
+		return
+	}
+
+	if digested {
+		if req.CryptoParams.HashingAlgorithm, err = hashingAlgorithm(hash); err != nil {
+			return
+		}
+
+		if len(digest) != hash.Size() {
+			err = errors.Errorf("digest length %d doesn't match hash function %v", len(digest), hash)
+			return
+		}
+
+		req.DigestedData = digest
+	}
+
+	var resp interface{}
+
+	if resp, err = s.send(OPERATION_SIGN, req); err != nil {
+		err = errors.Wrap(err, "error signing")
+		return
+	}
+
+	signature = resp.(SignResponse).SignatureData
+
+	return
+}
+
+// Decrypt implements crypto.Decrypter interface
+//
+// Only RSA keys are supported, opts might be nil or *rsa.PKCS1v15DecryptOptions for
+// PKCS#1 v1.5 padding or *rsa.OAEPOptions for OAEP padding.
+func (s *Signer) Decrypt(rand io.Reader, msg []byte, opts crypto.DecrypterOpts) (plaintext []byte, err error) {
+	if _, ok := s.public.(*rsa.PublicKey); !ok {
+		err = errors.Errorf("decryption is not supported for public key type %T", s.public)
+		return
+	}
+
+	req := DecryptRequest{
+		UniqueIdentifier: s.uid,
+		CryptoParams: CryptoParams{
+			CryptographicAlgorithm: CRYPTO_RSA,
+		},
+		Data: msg,
+	}
+
+	switch o := opts.(type) {
+	case nil, *rsa.PKCS1v15DecryptOptions:
+		req.CryptoParams.PaddingMethod = PADDING_METHOD_PKCS_1_V1_5
+	case *rsa.OAEPOptions:
+		req.CryptoParams.PaddingMethod = PADDING_METHOD_OAEP
+		req.CryptoParams.PSource = o.Label
+
+		if req.CryptoParams.HashingAlgorithm, err = hashingAlgorithm(o.Hash); err != nil {
+			return
+		}
+	default:
+		err = errors.Errorf("unsupported decrypter options %T", opts)
+		return
+	}
+
+	var resp interface{}
+
+	if resp, err = s.send(OPERATION_DECRYPT, req); err != nil {
+		err = errors.Wrap(err, "error decrypting")
+		return
+	}
+
+	plaintext = resp.(DecryptResponse).Data
+
+	return
+}
+
+func (s *Signer) send(operation Enum, req interface{}) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.client.Send(operation, req)
+}
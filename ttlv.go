@@ -0,0 +1,218 @@
+package kmip
+
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// TTLV is a generic (untyped) representation of TTLV item
+//
+// TTLV can hold any well-formed KMIP message without knowing its
+// structure in advance. For structures, Children hold nested items
+// and Value is nil. For other types Value holds Go value of
+// the respective core type (see Encoder for the mapping).
+//
+// TTLV implements Marshaler and Unmarshaler, so it could be used both
+// as a top-level value and as a field type in other structs. Decoded TTLV
+// is encoded back byte-for-byte: Big Integers which were not minimally encoded
+// (e.g. sign-extended to 16 bytes) keep their original encoding as long as
+// Value is not changed.
+type TTLV struct {
+	Tag      Tag
+	Type     Type
+	Value    interface{}
+	Children []TTLV
+
+	// original encoding of non-minimally encoded Big Integer
+	bigIntRaw []byte
+}
+
+// MarshalTTLV implements Marshaler interface
+//
+// If tag is ANY_TAG, TTLV own tag is used.
+func (t TTLV) MarshalTTLV(e *Encoder, tag Tag) (err error) {
+	if tag == ANY_TAG {
+		tag = t.Tag
+	}
+
+	ok := true
+
+	switch t.Type {
+	case STRUCTURE:
+		offset := e.beginStructure(tag)
+
+		for i := range t.Children {
+			if err = t.Children[i].MarshalTTLV(e, ANY_TAG); err != nil {
+				return
+			}
+		}
+
+		e.endStructure(offset)
+	case INTEGER:
+		var v int32
+		if v, ok = t.Value.(int32); ok {
+			e.writeInteger(tag, v)
+		}
+	case LONG_INTEGER:
+		var v int64
+		if v, ok = t.Value.(int64); ok {
+			e.writeLongInteger(tag, v)
+		}
+	case BIG_INTEGER:
+		var v *big.Int
+		if v, ok = t.Value.(*big.Int); ok && v != nil {
+			if t.bigIntRaw != nil && bigIntFromTwosComplement(t.bigIntRaw).Cmp(v) == 0 {
+				e.writeBigIntegerRaw(tag, t.bigIntRaw)
+			} else {
+				e.writeBigInteger(tag, v)
+			}
+		} else {
+			ok = false
+		}
+	case ENUMERATION:
+		var v Enum
+		if v, ok = t.Value.(Enum); ok {
+			e.writeEnum(tag, v)
+		}
+	case BOOLEAN:
+		var v bool
+		if v, ok = t.Value.(bool); ok {
+			e.writeBool(tag, v)
+		}
+	case TEXT_STRING:
+		var v string
+		if v, ok = t.Value.(string); ok {
+			e.writeString(tag, v)
+		}
+	case BYTE_STRING:
+		var v []byte
+		if v, ok = t.Value.([]byte); ok {
+			e.writeBytes(tag, v)
+		}
+	case DATE_TIME:
+		var v time.Time
+		if v, ok = t.Value.(time.Time); ok {
+			e.writeTime(tag, v)
+		}
+	case INTERVAL:
+		var v time.Duration
+		if v, ok = t.Value.(time.Duration); ok {
+			e.writeDuration(tag, v)
+		}
+	default:
+		err = errors.Errorf("unsupported type %d for tag %x", t.Type, uint32(tag))
+	}
+
+	if !ok {
+		err = errors.Errorf("unexpected value %T for type %d, tag %x", t.Value, t.Type, uint32(tag))
+	}
+
+	return
+}
+
+// UnmarshalTTLV implements Unmarshaler interface
+func (t *TTLV) UnmarshalTTLV(d *Decoder, tag Tag) error {
+	_, err := t.decode(d, tag)
+	return err
+}
+
+func (t *TTLV) decode(d *Decoder, tag Tag) (n int, err error) {
+	if t.Tag, err = d.peekTag(); err != nil {
+		return
+	}
+
+	if t.Type, err = d.peekType(); err != nil {
+		return
+	}
+
+	t.Value, t.Children, t.bigIntRaw = nil, nil, nil
+
+	switch t.Type {
+	case STRUCTURE:
+		if err = d.expectTag(tag); err != nil {
+			return
+		}
+
+		if err = d.expectType(STRUCTURE); err != nil {
+			return
+		}
+
+		var expectedLen uint32
+		if expectedLen, err = d.readLength(); err != nil {
+			return
+		}
+
+		n = 8
+
+		var dd *Decoder
+		if dd, err = d.structure(expectedLen); err != nil {
+			return
+		}
+
+		for actualLen := uint32(0); actualLen < expectedLen; {
+			var (
+				child TTLV
+				nn    int
+			)
+
+			offset := dd.offset()
+
+			nn, err = child.decode(dd, ANY_TAG)
+			if err != nil {
+				err = wrapDecodeError(err, tagToText(child.Tag), offset)
+				return
+			}
+
+			t.Children = append(t.Children, child)
+
+			n += nn
+			actualLen += uint32(nn)
+		}
+	case INTEGER:
+		t.Value, err = d.readInteger(tag)
+		n = 16
+	case LONG_INTEGER:
+		t.Value, err = d.readLongInteger(tag)
+		n = 16
+	case BIG_INTEGER:
+		var (
+			v   *big.Int
+			raw []byte
+		)
+
+		n, v, raw, err = d.readBigIntegerRaw(tag)
+		if err == nil {
+			t.Value = v
+
+			if len(raw) != bigIntegerLength(v) {
+				t.bigIntRaw = raw
+			}
+		}
+	case ENUMERATION:
+		t.Value, err = d.readEnum(tag)
+		n = 16
+	case BOOLEAN:
+		t.Value, err = d.readBool(tag)
+		n = 16
+	case TEXT_STRING:
+		n, t.Value, err = d.readString(tag)
+	case BYTE_STRING:
+		n, t.Value, err = d.readBytes(tag)
+	case DATE_TIME:
+		t.Value, err = d.readTime(tag)
+		n = 16
+	case INTERVAL:
+		t.Value, err = d.readDuration(tag)
+		n = 16
+	default:
+		err = errors.Errorf("unsupported type %d for tag %x", t.Type, uint32(t.Tag))
+	}
+
+	return
+}
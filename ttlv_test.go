@@ -0,0 +1,166 @@
+package kmip
+
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TTLVSuite struct {
+	suite.Suite
+}
+
+func (s *TTLVSuite) parseSpecValue(val string) []byte {
+	val = strings.ReplaceAll(strings.ReplaceAll(strings.ReplaceAll(val, "_", ""), "|", ""), " ", "")
+
+	res, err := hex.DecodeString(val)
+	s.Require().NoError(err)
+
+	return res
+}
+
+func (s *TTLVSuite) TestDecodeMessageGet() {
+	var t TTLV
+
+	err := NewDecoder(bytes.NewReader(messageGet)).Decode(&t)
+	s.Require().NoError(err)
+
+	s.Assert().Equal(TTLV{
+		Tag:  REQUEST_MESSAGE,
+		Type: STRUCTURE,
+		Children: []TTLV{
+			{
+				Tag:  REQUEST_HEADER,
+				Type: STRUCTURE,
+				Children: []TTLV{
+					{
+						Tag:  PROTOCOL_VERSION,
+						Type: STRUCTURE,
+						Children: []TTLV{
+							{Tag: PROTOCOL_VERSION_MAJOR, Type: INTEGER, Value: int32(1)},
+							{Tag: PROTOCOL_VERSION_MINOR, Type: INTEGER, Value: int32(1)},
+						},
+					},
+					{Tag: BATCH_COUNT, Type: INTEGER, Value: int32(1)},
+				},
+			},
+			{
+				Tag:  BATCH_ITEM,
+				Type: STRUCTURE,
+				Children: []TTLV{
+					{Tag: OPERATION, Type: ENUMERATION, Value: OPERATION_GET},
+					{
+						Tag:  REQUEST_PAYLOAD,
+						Type: STRUCTURE,
+						Children: []TTLV{
+							{Tag: UNIQUE_IDENTIFIER, Type: TEXT_STRING, Value: "49a1ca88-6bea-4fb2-b450-7e58802c3038"},
+						},
+					},
+				},
+			},
+		},
+	}, t)
+}
+
+func (s *TTLVSuite) TestRoundTrip() {
+	for _, message := range [][]byte{messageCreate, messageGet} {
+		var t TTLV
+
+		err := NewDecoder(bytes.NewReader(message)).Decode(&t)
+		s.Require().NoError(err)
+
+		var buf bytes.Buffer
+
+		err = NewEncoder(&buf).Encode(t)
+		s.Require().NoError(err)
+
+		s.Assert().Equal(message, buf.Bytes())
+	}
+}
+
+func (s *TTLVSuite) TestRoundTripBigInteger() {
+	// 0x10001 sign-extended to 16 bytes, minimal encoding is 8 bytes
+	data := s.parseSpecValue("42 00 20 | 04 | 00 00 00 10 | 00 00 00 00 00 00 00 00 00 00 00 00 00 01 00 01")
+
+	var t TTLV
+
+	s.Require().NoError(NewDecoder(bytes.NewReader(data)).Decode(&t))
+	s.Assert().Equal(big.NewInt(0x10001), t.Value)
+
+	var buf bytes.Buffer
+
+	s.Require().NoError(NewEncoder(&buf).Encode(t))
+	s.Assert().Equal(data, buf.Bytes())
+
+	// changed value is encoded minimally
+	t.Value = big.NewInt(-0x10001)
+
+	buf.Reset()
+	s.Require().NoError(NewEncoder(&buf).Encode(t))
+	s.Assert().Equal(s.parseSpecValue("42 00 20 | 04 | 00 00 00 08 | FF FF FF FF FF FE FF FF"), buf.Bytes())
+}
+
+func (s *TTLVSuite) TestAllTypes() {
+	t := TTLV{
+		Tag:  COMPROMISE_DATE,
+		Type: STRUCTURE,
+		Children: []TTLV{
+			{Tag: ARCHIVE_DATE, Type: INTEGER, Value: int32(-5)},
+			{Tag: ARCHIVE_DATE, Type: LONG_INTEGER, Value: int64(123456789000000000)},
+			{Tag: ARCHIVE_DATE, Type: BIG_INTEGER, Value: big.NewInt(-129)},
+			{Tag: ARCHIVE_DATE, Type: ENUMERATION, Value: Enum(255)},
+			{Tag: ARCHIVE_DATE, Type: BOOLEAN, Value: true},
+			{Tag: ARCHIVE_DATE, Type: TEXT_STRING, Value: "Hello World"},
+			{Tag: ARCHIVE_DATE, Type: BYTE_STRING, Value: []byte{1, 2, 3}},
+			{Tag: ARCHIVE_DATE, Type: DATE_TIME, Value: time.Unix(1205495800, 0)},
+			{Tag: ARCHIVE_DATE, Type: INTERVAL, Value: 10 * 24 * time.Hour},
+			{Tag: ARCHIVE_DATE, Type: STRUCTURE},
+		},
+	}
+
+	var buf bytes.Buffer
+
+	s.Require().NoError(NewEncoder(&buf).Encode(&t))
+
+	var t2 TTLV
+
+	s.Require().NoError(NewDecoder(bytes.NewReader(buf.Bytes())).Decode(&t2))
+	s.Assert().Equal(t, t2)
+
+	err := NewEncoder(&buf).Encode(TTLV{Tag: ARCHIVE_DATE, Type: INTEGER, Value: "xx"})
+	s.Assert().EqualError(err, "unexpected value string for type 2, tag 420005")
+}
+
+func (s *TTLVSuite) TestField() {
+	type tt struct {
+		Tag `kmip:"COMPROMISE_DATE"`
+		A   Enum `kmip:"APPLICATION_SPECIFIC_INFORMATION,required"`
+		B   TTLV `kmip:"ARCHIVE_DATE"`
+	}
+
+	data := s.parseSpecValue("42 00 20 | 01 | 00 00 00 20 | 42 00 04 | 05 | 00 00 00 04 | 00 00 00 FE 00 00 00 00 |" +
+		" 42 00 05 | 02 | 00 00 00 04 | 00 00 00 FF 00 00 00 00")
+
+	var v tt
+
+	s.Require().NoError(NewDecoder(bytes.NewReader(data)).Decode(&v))
+	s.Assert().Equal(tt{A: 254, B: TTLV{Tag: ARCHIVE_DATE, Type: INTEGER, Value: int32(255)}}, v)
+
+	var buf bytes.Buffer
+
+	s.Require().NoError(NewEncoder(&buf).Encode(&v))
+	s.Assert().Equal(data, buf.Bytes())
+}
+
+func TestTTLVSuite(t *testing.T) {
+	suite.Run(t, new(TTLVSuite))
+}
@@ -5,32 +5,137 @@ package kmip
  * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
 
 import (
+	"fmt"
+	"math/big"
 	"reflect"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/pkg/errors"
 )
 
 // Tag is a number that designates the specific Protocol Field or Object that the TTLV object represents
 type Tag uint32
 
+// String returns tag name (e.g. CRYPTOGRAPHIC_ALGORITHM) or hex value for unknown tags
+func (t Tag) String() string {
+	if name, ok := tagNames[t]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("0x%06x", uint32(t))
+}
+
 // Type is a byte containing a coded value that indicates the data type of the data object
 type Type uint8
 
+// String returns type name (e.g. LONG_INTEGER) or hex value for unknown types
+func (t Type) String() string {
+	switch t {
+	case STRUCTURE:
+		return "STRUCTURE"
+	case INTEGER:
+		return "INTEGER"
+	case LONG_INTEGER:
+		return "LONG_INTEGER"
+	case BIG_INTEGER:
+		return "BIG_INTEGER"
+	case ENUMERATION:
+		return "ENUMERATION"
+	case BOOLEAN:
+		return "BOOLEAN"
+	case TEXT_STRING:
+		return "TEXT_STRING"
+	case BYTE_STRING:
+		return "BYTE_STRING"
+	case DATE_TIME:
+		return "DATE_TIME"
+	case INTERVAL:
+		return "INTERVAL"
+	default:
+		return fmt.Sprintf("0x%02x", uint8(t))
+	}
+}
+
 // Enum is KMIP Enumeration type
 type Enum uint32
 
+// EnumName returns name of enumeration value in the context of the tag
+//
+// Enumeration values are not unique across enumerations, so the tag
+// of the field is required, e.g.: EnumName(CRYPTOGRAPHIC_ALGORITHM, 3) -> "AES".
+// If the value is not known, hex value is returned.
+func EnumName(tag Tag, v Enum) string {
+	if name, ok := enumNames[tag][v]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("0x%08x", uint32(v))
+}
+
+// ParseEnum parses enumeration value in the context of the tag
+//
+// Value might be specified either by name (e.g. "AES", case and
+// underscores are ignored) or as hex value ("0x00000003").
+func ParseEnum(tag Tag, s string) (Enum, error) {
+	if strings.HasPrefix(s, "0x") {
+		v, err := strconv.ParseUint(s[2:], 16, 32)
+		if err != nil {
+			return 0, errors.Wrapf(err, "error parsing enumeration value %q", s)
+		}
+
+		return Enum(v), nil
+	}
+
+	normalized := normalizeName(s)
+
+	for v, name := range enumNames[tag] {
+		if normalizeName(name) == normalized {
+			return v, nil
+		}
+	}
+
+	return 0, errors.Errorf("unknown enumeration value %q for tag %s", s, tag)
+}
+
 // DynamicDispatch is an interface for structure go set field value based on other field values
+//
+// BuildFieldValue might return nil value to have the field decoded according to the
+// type on the wire: primitive values are decoded into respective Go types (see Encoder),
+// structures are decoded as TTLV.
 type DynamicDispatch interface {
 	BuildFieldValue(name string) (interface{}, error)
 }
 
+// Marshaler is an interface implemented by types which can encode themselves into TTLV
+//
+// MarshalTTLV should encode single TTLV item with tag tag (ANY_TAG if the
+// value is encoded at the top level, in that case type should pick the tag itself).
+type Marshaler interface {
+	MarshalTTLV(e *Encoder, tag Tag) error
+}
+
+// Unmarshaler is an interface implemented by types which can decode TTLV representation of themselves
+//
+// UnmarshalTTLV receives decoder positioned at the TTLV item with tag tag (or ANY_TAG
+// if the tag is not known in advance).
+type Unmarshaler interface {
+	UnmarshalTTLV(d *Decoder, tag Tag) error
+}
+
 var (
 	typeOfTag      = reflect.TypeOf(Tag(0))
 	typeOfEnum     = reflect.TypeOf(Enum(0))
 	typeOfInt32    = reflect.TypeOf(int32(0))
 	typeOfInt64    = reflect.TypeOf(int64(0))
+	typeOfBigInt   = reflect.TypeOf((*big.Int)(nil))
 	typeOfBool     = reflect.TypeOf(false)
 	typeOfBytes    = reflect.TypeOf([]byte(nil))
 	typeOfString   = reflect.TypeOf("")
 	typeOfTime     = reflect.TypeOf(time.Time{})
 	typeOfDuration = reflect.TypeOf(time.Duration(0))
+
+	typeOfMarshaler   = reflect.TypeOf((*Marshaler)(nil)).Elem()
+	typeOfUnmarshaler = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
 )
@@ -0,0 +1,67 @@
+package kmip
+
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TypesSuite struct {
+	suite.Suite
+}
+
+func (s *TypesSuite) TestTagString() {
+	s.Assert().Equal("CRYPTOGRAPHIC_ALGORITHM", CRYPTOGRAPHIC_ALGORITHM.String())
+	s.Assert().Equal("BATCH_ITEM", RESPONSE_BATCH_ITEM.String())
+	s.Assert().Equal("0x540001", Tag(0x540001).String())
+	s.Assert().Equal("tag UNIQUE_IDENTIFIER", fmt.Sprintf("tag %v", UNIQUE_IDENTIFIER))
+}
+
+func (s *TypesSuite) TestTypeString() {
+	s.Assert().Equal("STRUCTURE", STRUCTURE.String())
+	s.Assert().Equal("LONG_INTEGER", LONG_INTEGER.String())
+	s.Assert().Equal("INTERVAL", INTERVAL.String())
+	s.Assert().Equal("0x0c", Type(12).String())
+}
+
+func (s *TypesSuite) TestEnumName() {
+	s.Assert().Equal("AES", EnumName(CRYPTOGRAPHIC_ALGORITHM, CRYPTO_AES))
+	s.Assert().Equal("GET", EnumName(OPERATION, OPERATION_GET))
+	s.Assert().Equal("SHA256", EnumName(HASHING_ALGORITHM, HASH_SHA256))
+	s.Assert().Equal("SHA256", EnumName(MASK_GENERATOR_HASHING_ALGORITHM, HASH_SHA256))
+	s.Assert().Equal("SYMMETRIC_KEY", EnumName(OBJECT_TYPE, OBJECT_TYPE_SYMMETRIC_KEY))
+	s.Assert().Equal("0x00000003", EnumName(UNIQUE_IDENTIFIER, 3))
+	s.Assert().Equal("0x000000ff", EnumName(OPERATION, 255))
+}
+
+func (s *TypesSuite) TestParseEnum() {
+	for _, v := range []string{"AES", "aes", "0x00000003"} {
+		e, err := ParseEnum(CRYPTOGRAPHIC_ALGORITHM, v)
+		s.Require().NoError(err)
+		s.Assert().Equal(CRYPTO_AES, e)
+	}
+
+	for _, v := range []string{"SYMMETRIC_KEY", "SymmetricKey", "symmetric-key"} {
+		e, err := ParseEnum(OBJECT_TYPE, v)
+		s.Require().NoError(err)
+		s.Assert().Equal(OBJECT_TYPE_SYMMETRIC_KEY, e)
+	}
+
+	_, err := ParseEnum(CRYPTOGRAPHIC_ALGORITHM, "FOO")
+	s.Assert().EqualError(err, "unknown enumeration value \"FOO\" for tag CRYPTOGRAPHIC_ALGORITHM")
+
+	_, err = ParseEnum(UNIQUE_IDENTIFIER, "AES")
+	s.Assert().EqualError(err, "unknown enumeration value \"AES\" for tag UNIQUE_IDENTIFIER")
+
+	_, err = ParseEnum(OPERATION, "0xzz")
+	s.Assert().Error(err)
+}
+
+func TestTypesSuite(t *testing.T) {
+	suite.Run(t, new(TypesSuite))
+}
@@ -0,0 +1,349 @@
+package kmip
+
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+import (
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// XMLEncoder implements encoding to KMIP XML encoding
+//
+// Values are encoded exactly the same way as with Encoder (using the
+// same `kmip` struct tags), but the output is KMIP XML:
+//
+//	<RequestMessage><RequestHeader><ProtocolVersion>
+//	<ProtocolVersionMajor type="Integer" value="1"/>...
+type XMLEncoder struct {
+	w io.Writer
+
+	prefix, indent string
+}
+
+// NewXMLEncoder builds XML encoder writing to w
+func NewXMLEncoder(w io.Writer) *XMLEncoder {
+	return &XMLEncoder{
+		w: w,
+	}
+}
+
+// Indent enables indentation of the output (see xml.Encoder.Indent)
+func (e *XMLEncoder) Indent(prefix, indent string) {
+	e.prefix, e.indent = prefix, indent
+}
+
+// Encode v as KMIP XML
+func (e *XMLEncoder) Encode(v interface{}) error {
+	t, err := toTTLV(v)
+	if err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(e.w)
+	enc.Indent(e.prefix, e.indent)
+
+	return enc.Encode(t)
+}
+
+// XMLDecoder implements decoding from KMIP XML encoding
+//
+// Items with tags which don't have names are represented as
+// <TTLV tag="0x540001" type="Integer" value="1"/>, type might be
+// omitted for structures.
+type XMLDecoder struct {
+	// Relaxed enables order-insensitive, unknown-field-tolerant decoding (see Decoder)
+	Relaxed bool
+
+	d *xml.Decoder
+}
+
+// NewXMLDecoder builds XML decoder which reads from r
+func NewXMLDecoder(r io.Reader) *XMLDecoder {
+	return &XMLDecoder{
+		d: xml.NewDecoder(r),
+	}
+}
+
+// Decode KMIP XML from the reader into v
+func (d *XMLDecoder) Decode(v interface{}) error {
+	var t TTLV
+
+	if err := d.d.Decode(&t); err != nil {
+		return err
+	}
+
+	return fromTTLV(t, v, d.Relaxed)
+}
+
+const xmlGenericElement = "TTLV"
+
+// MarshalXML implements xml.Marshaler
+//
+// TTLV is encoded according to KMIP XML encoding.
+func (t TTLV) MarshalXML(e *xml.Encoder, _ xml.StartElement) (err error) {
+	var start xml.StartElement
+
+	if _, ok := tagNames[t.Tag]; ok {
+		start.Name.Local = tagToText(t.Tag)
+	} else {
+		start.Name.Local = xmlGenericElement
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "tag"}, Value: tagToText(t.Tag)})
+	}
+
+	if t.Type < STRUCTURE || t.Type > INTERVAL {
+		return errors.Errorf("unsupported type %d for tag %x", t.Type, uint32(t.Tag))
+	}
+
+	if t.Type != STRUCTURE {
+		var value string
+
+		if value, err = xmlValueToText(t); err != nil {
+			return
+		}
+
+		start.Attr = append(start.Attr,
+			xml.Attr{Name: xml.Name{Local: "type"}, Value: typeToText(t.Type)},
+			xml.Attr{Name: xml.Name{Local: "value"}, Value: value},
+		)
+	}
+
+	if err = e.EncodeToken(start); err != nil {
+		return
+	}
+
+	for i := range t.Children {
+		if err = e.Encode(t.Children[i]); err != nil {
+			return
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+// UnmarshalXML implements xml.Unmarshaler
+//
+// TTLV is decoded according to KMIP XML encoding.
+func (t *TTLV) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return t.unmarshalXML(d, start, "")
+}
+
+// unmarshalXML decodes TTLV, attributeName is the name of the enclosing attribute (if any)
+func (t *TTLV) unmarshalXML(d *xml.Decoder, start xml.StartElement, attributeName string) (err error) {
+	tagName := start.Name.Local
+	typeName := typeToText(STRUCTURE)
+
+	var (
+		value    string
+		hasValue bool
+	)
+
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "tag":
+			tagName = attr.Value
+		case "type":
+			typeName = attr.Value
+		case "value":
+			value, hasValue = attr.Value, true
+		}
+	}
+
+	if t.Tag, err = tagFromText(tagName); err != nil {
+		return
+	}
+
+	if t.Type, err = typeFromText(typeName); err != nil {
+		return
+	}
+
+	t.Value, t.Children = nil, nil
+
+	if t.Type != STRUCTURE {
+		if !hasValue {
+			return errors.Errorf("missing value for tag %s", tagName)
+		}
+
+		if err = xmlValueFromText(t, value, attributeName); err != nil {
+			return errors.Wrapf(err, "error decoding value for tag %s", tagName)
+		}
+
+		return d.Skip()
+	}
+
+	var name string
+
+	for {
+		var token xml.Token
+
+		if token, err = d.Token(); err != nil {
+			return
+		}
+
+		switch tok := token.(type) {
+		case xml.StartElement:
+			var child TTLV
+
+			if err = child.unmarshalXML(d, tok, name); err != nil {
+				return
+			}
+
+			if child.Tag == ATTRIBUTE_NAME {
+				name, _ = child.Value.(string)
+			}
+
+			t.Children = append(t.Children, child)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+func xmlValueToText(t TTLV) (v string, err error) {
+	ok := true
+
+	switch t.Type {
+	case INTEGER:
+		var i int32
+		if i, ok = t.Value.(int32); ok {
+			v = strconv.FormatInt(int64(i), 10)
+		}
+	case LONG_INTEGER:
+		var i int64
+		if i, ok = t.Value.(int64); ok {
+			v = strconv.FormatInt(i, 10)
+		}
+	case BIG_INTEGER:
+		var i *big.Int
+		if i, ok = t.Value.(*big.Int); ok && i != nil {
+			v = bigIntToHex(i)
+		} else {
+			ok = false
+		}
+	case ENUMERATION:
+		var e Enum
+		if e, ok = t.Value.(Enum); ok {
+			v = fmt.Sprintf("0x%08x", uint32(e))
+		}
+	case BOOLEAN:
+		var b bool
+		if b, ok = t.Value.(bool); ok {
+			v = strconv.FormatBool(b)
+		}
+	case TEXT_STRING:
+		v, ok = t.Value.(string)
+	case BYTE_STRING:
+		var b []byte
+		if b, ok = t.Value.([]byte); ok {
+			v = hex.EncodeToString(b)
+		}
+	case DATE_TIME:
+		var tm time.Time
+		if tm, ok = t.Value.(time.Time); ok {
+			v = tm.UTC().Format(time.RFC3339)
+		}
+	case INTERVAL:
+		var d time.Duration
+		if d, ok = t.Value.(time.Duration); ok {
+			v = strconv.FormatInt(int64(d/time.Second), 10)
+		}
+	}
+
+	if !ok {
+		err = errors.Errorf("unexpected value %T for type %d, tag %x", t.Value, t.Type, uint32(t.Tag))
+	}
+
+	return
+}
+
+// xmlValueFromText decodes value of t, attributeName is the name of the enclosing attribute (if any)
+//
+// Enumerations and Cryptographic Usage Mask might be specified by name, names of
+// Attribute Value are resolved through the attribute name.
+func xmlValueFromText(t *TTLV, v string, attributeName string) (err error) {
+	namesTag := enumNamesTag(t.Tag, attributeName)
+
+	switch t.Type {
+	case INTEGER:
+		var i int64
+
+		switch {
+		case strings.HasPrefix(v, "0x"):
+			i, err = parseHexInt(v, 32)
+		case namesTag == CRYPTOGRAPHIC_USAGE_MASK && v != "" && (v[0] < '0' || v[0] > '9') && v[0] != '-':
+			var m UsageMask
+
+			m, err = parseUsageMask(v)
+			i = int64(int32(m))
+		default:
+			i, err = strconv.ParseInt(v, 10, 32)
+		}
+
+		t.Value = int32(i)
+	case LONG_INTEGER:
+		var i int64
+
+		if strings.HasPrefix(v, "0x") {
+			i, err = parseHexInt(v, 64)
+		} else {
+			i, err = strconv.ParseInt(v, 10, 64)
+		}
+
+		t.Value = i
+	case BIG_INTEGER:
+		if strings.HasPrefix(v, "0x") {
+			t.Value, err = bigIntFromHex(v)
+		} else {
+			i, ok := new(big.Int).SetString(v, 10)
+			if !ok {
+				err = errors.Errorf("invalid big integer value %q", v)
+			}
+
+			t.Value = i
+		}
+	case ENUMERATION:
+		if len(v) > 0 && v[0] >= '0' && v[0] <= '9' && !strings.HasPrefix(v, "0x") {
+			var i uint64
+
+			i, err = strconv.ParseUint(v, 10, 32)
+			t.Value = Enum(i)
+		} else {
+			t.Value, err = ParseEnum(namesTag, v)
+		}
+	case BOOLEAN:
+		t.Value, err = strconv.ParseBool(v)
+	case TEXT_STRING:
+		t.Value = v
+	case BYTE_STRING:
+		t.Value, err = hex.DecodeString(v)
+	case DATE_TIME:
+		var tm time.Time
+
+		if strings.HasPrefix(v, "0x") {
+			var i int64
+
+			i, err = parseHexInt(v, 64)
+			tm = time.Unix(i, 0)
+		} else {
+			tm, err = time.Parse(time.RFC3339, v)
+		}
+
+		t.Value = tm
+	case INTERVAL:
+		var i uint64
+
+		i, err = strconv.ParseUint(v, 10, 32)
+		t.Value = time.Duration(i) * time.Second
+	}
+
+	return
+}
@@ -0,0 +1,233 @@
+package kmip
+
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+import (
+	"bytes"
+	"encoding/xml"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type XMLSuite struct {
+	suite.Suite
+}
+
+const xmlMessageGet = `<RequestMessage>
+  <RequestHeader>
+    <ProtocolVersion>
+      <ProtocolVersionMajor type="Integer" value="1"></ProtocolVersionMajor>
+      <ProtocolVersionMinor type="Integer" value="1"></ProtocolVersionMinor>
+    </ProtocolVersion>
+    <BatchCount type="Integer" value="1"></BatchCount>
+  </RequestHeader>
+  <BatchItem>
+    <Operation type="Enumeration" value="0x0000000a"></Operation>
+    <RequestPayload>
+      <UniqueIdentifier type="TextString" value="49a1ca88-6bea-4fb2-b450-7e58802c3038"></UniqueIdentifier>
+    </RequestPayload>
+  </BatchItem>
+</RequestMessage>`
+
+func (s *XMLSuite) TestEncodeMessageGet() {
+	var m Request
+
+	s.Require().NoError(NewDecoder(bytes.NewReader(messageGet)).Decode(&m))
+
+	var buf bytes.Buffer
+
+	enc := NewXMLEncoder(&buf)
+	enc.Indent("", "  ")
+
+	s.Require().NoError(enc.Encode(&m))
+	s.Assert().Equal(xmlMessageGet, buf.String())
+}
+
+func (s *XMLSuite) TestDecodeMessageGet() {
+	// self-closing elements, as in KMIP test vectors
+	message := `<RequestMessage>
+  <RequestHeader>
+    <ProtocolVersion>
+      <ProtocolVersionMajor type="Integer" value="1"/>
+      <ProtocolVersionMinor type="Integer" value="1"/>
+    </ProtocolVersion>
+    <BatchCount type="Integer" value="1"/>
+  </RequestHeader>
+  <BatchItem>
+    <Operation type="Enumeration" value="10"/>
+    <RequestPayload type="Structure">
+      <UniqueIdentifier type="TextString" value="49a1ca88-6bea-4fb2-b450-7e58802c3038"/>
+    </RequestPayload>
+  </BatchItem>
+</RequestMessage>`
+
+	var m Request
+
+	s.Require().NoError(NewXMLDecoder(strings.NewReader(message)).Decode(&m))
+	s.Assert().Equal(Request{
+		Header: RequestHeader{
+			Version:    ProtocolVersion{Major: 1, Minor: 1},
+			BatchCount: 1,
+		},
+		BatchItems: []RequestBatchItem{
+			{
+				Operation: OPERATION_GET,
+				RequestPayload: GetRequest{
+					UniqueIdentifier: "49a1ca88-6bea-4fb2-b450-7e58802c3038",
+				},
+			},
+		},
+	}, m)
+}
+
+func (s *XMLSuite) TestDecodeTestVectors() {
+	// requests in the form of OASIS KMIP 1.4 XML test vectors: enumerations
+	// and usage mask are specified by name, including attribute values
+	create := `<RequestMessage>
+  <RequestHeader>
+    <ProtocolVersion>
+      <ProtocolVersionMajor type="Integer" value="1"/>
+      <ProtocolVersionMinor type="Integer" value="4"/>
+    </ProtocolVersion>
+    <BatchCount type="Integer" value="1"/>
+  </RequestHeader>
+  <BatchItem>
+    <Operation type="Enumeration" value="Create"/>
+    <RequestPayload>
+      <ObjectType type="Enumeration" value="SymmetricKey"/>
+      <TemplateAttribute>
+        <Attribute>
+          <AttributeName type="TextString" value="Cryptographic Algorithm"/>
+          <AttributeValue type="Enumeration" value="AES"/>
+        </Attribute>
+        <Attribute>
+          <AttributeName type="TextString" value="Cryptographic Length"/>
+          <AttributeValue type="Integer" value="128"/>
+        </Attribute>
+        <Attribute>
+          <AttributeName type="TextString" value="Cryptographic Usage Mask"/>
+          <AttributeValue type="Integer" value="Decrypt Encrypt"/>
+        </Attribute>
+        <Attribute>
+          <AttributeName type="TextString" value="Name"/>
+          <AttributeValue>
+            <NameValue type="TextString" value="SKLC-M-1-14"/>
+            <NameType type="Enumeration" value="UninterpretedTextString"/>
+          </AttributeValue>
+        </Attribute>
+      </TemplateAttribute>
+    </RequestPayload>
+  </BatchItem>
+</RequestMessage>`
+
+	var m Request
+
+	s.Require().NoError(NewXMLDecoder(strings.NewReader(create)).Decode(&m))
+	s.Require().Len(m.BatchItems, 1)
+	s.Assert().Equal(OPERATION_CREATE, m.BatchItems[0].Operation)
+
+	req := m.BatchItems[0].RequestPayload.(CreateRequest)
+	s.Assert().Equal(OBJECT_TYPE_SYMMETRIC_KEY, req.ObjectType)
+
+	attrs := req.TemplateAttribute.Attributes
+	s.Assert().Equal(CRYPTO_AES, attrs.Get(ATTRIBUTE_NAME_CRYPTOGRAPHIC_ALGORITHM))
+	s.Assert().Equal(int32(128), attrs.Get(ATTRIBUTE_NAME_CRYPTOGRAPHIC_LENGTH))
+	s.Assert().Equal(CRYPTO_USAGE_MASK_DECRYPT|CRYPTO_USAGE_MASK_ENCRYPT, attrs.Get(ATTRIBUTE_NAME_CRYPTOGRAPHIC_USAGE_MASK))
+
+	name, ok := attrs.GetName()
+	s.Require().True(ok)
+	s.Assert().Equal(Name{Value: "SKLC-M-1-14", Type: NAME_TYPE_UNINTERPRETED_TEXT_STRING}, name)
+
+	locate := `<RequestMessage>
+  <RequestHeader>
+    <ProtocolVersion>
+      <ProtocolVersionMajor type="Integer" value="1"/>
+      <ProtocolVersionMinor type="Integer" value="4"/>
+    </ProtocolVersion>
+    <BatchCount type="Integer" value="1"/>
+  </RequestHeader>
+  <BatchItem>
+    <Operation type="Enumeration" value="Locate"/>
+    <RequestPayload>
+      <Attribute>
+        <AttributeName type="TextString" value="Object Type"/>
+        <AttributeValue type="Enumeration" value="SymmetricKey"/>
+      </Attribute>
+      <Attribute>
+        <AttributeName type="TextString" value="Name"/>
+        <AttributeValue>
+          <NameValue type="TextString" value="SKLC-M-1-14"/>
+          <NameType type="Enumeration" value="UninterpretedTextString"/>
+        </AttributeValue>
+      </Attribute>
+    </RequestPayload>
+  </BatchItem>
+</RequestMessage>`
+
+	m = Request{}
+
+	s.Require().NoError(NewXMLDecoder(strings.NewReader(locate)).Decode(&m))
+	s.Require().Len(m.BatchItems, 1)
+	s.Assert().Equal(OPERATION_LOCATE, m.BatchItems[0].Operation)
+	s.Assert().Equal(OBJECT_TYPE_SYMMETRIC_KEY, m.BatchItems[0].RequestPayload.(LocateRequest).Attributes.Get(ATTRIBUTE_NAME_OBJECT_TYPE))
+}
+
+func (s *XMLSuite) TestAllTypes() {
+	t := TTLV{
+		Tag:  COMPROMISE_DATE,
+		Type: STRUCTURE,
+		Children: []TTLV{
+			{Tag: ARCHIVE_DATE, Type: INTEGER, Value: int32(-5)},
+			{Tag: ARCHIVE_DATE, Type: LONG_INTEGER, Value: int64(123456789000000000)},
+			{Tag: ARCHIVE_DATE, Type: BIG_INTEGER, Value: big.NewInt(-129)},
+			{Tag: ARCHIVE_DATE, Type: ENUMERATION, Value: Enum(255)},
+			{Tag: ARCHIVE_DATE, Type: BOOLEAN, Value: true},
+			{Tag: ARCHIVE_DATE, Type: TEXT_STRING, Value: "Hello <World>"},
+			{Tag: ARCHIVE_DATE, Type: BYTE_STRING, Value: []byte{1, 2, 3}},
+			{Tag: ARCHIVE_DATE, Type: DATE_TIME, Value: time.Date(2008, 3, 14, 11, 56, 40, 0, time.UTC)},
+			{Tag: ARCHIVE_DATE, Type: INTERVAL, Value: 10 * 24 * time.Hour},
+			{Tag: 0x540001, Type: INTEGER, Value: int32(1)},
+		},
+	}
+
+	data, err := xml.Marshal(t)
+	s.Require().NoError(err)
+
+	s.Assert().Equal(`<CompromiseDate>`+
+		`<ArchiveDate type="Integer" value="-5"></ArchiveDate>`+
+		`<ArchiveDate type="LongInteger" value="123456789000000000"></ArchiveDate>`+
+		`<ArchiveDate type="BigInteger" value="0xffffffffffffff7f"></ArchiveDate>`+
+		`<ArchiveDate type="Enumeration" value="0x000000ff"></ArchiveDate>`+
+		`<ArchiveDate type="Boolean" value="true"></ArchiveDate>`+
+		`<ArchiveDate type="TextString" value="Hello &lt;World&gt;"></ArchiveDate>`+
+		`<ArchiveDate type="ByteString" value="010203"></ArchiveDate>`+
+		`<ArchiveDate type="DateTime" value="2008-03-14T11:56:40Z"></ArchiveDate>`+
+		`<ArchiveDate type="Interval" value="864000"></ArchiveDate>`+
+		`<TTLV tag="0x540001" type="Integer" value="1"></TTLV>`+
+		`</CompromiseDate>`, string(data))
+
+	var t2 TTLV
+
+	s.Require().NoError(xml.Unmarshal(data, &t2))
+	s.Assert().Equal(t, t2)
+}
+
+func (s *XMLSuite) TestDecodeErrors() {
+	var t TTLV
+
+	s.Assert().EqualError(xml.Unmarshal([]byte(`<NoSuchTag/>`), &t), `unknown tag "NoSuchTag"`)
+	s.Assert().EqualError(xml.Unmarshal([]byte(`<ArchiveDate type="Float" value="1"/>`), &t), `unknown type "Float"`)
+	s.Assert().EqualError(xml.Unmarshal([]byte(`<ArchiveDate type="Integer"/>`), &t), `missing value for tag ArchiveDate`)
+	s.Assert().EqualError(xml.Unmarshal([]byte(`<CryptographicUsageMask type="Integer" value="Encrypt Frobnicate"/>`), &t),
+		`error decoding value for tag CryptographicUsageMask: unknown usage mask value "Frobnicate"`)
+}
+
+func TestXMLSuite(t *testing.T) {
+	suite.Run(t, new(XMLSuite))
+}